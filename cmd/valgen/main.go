@@ -0,0 +1,77 @@
+// Command valgen generates Rules methods from valgo struct tags, so schemas
+// get the tag rules without reflection at run time.
+//
+// Add a go generate directive to the file declaring the structs:
+//
+//	//go:generate go run github.com/mrbns/valgo/cmd/valgen
+//
+//	type User struct {
+//	    Name  string `json:"name" valgo:"required,max=40"`
+//	    Email string `json:"email" valgo:"required,email"`
+//	    Age   int    `json:"age"` // valgo: min=18
+//	}
+//
+// and run go generate. For user.go it writes user_valgo.go with
+// (*User).Rules built on v.NewPipesBuilder and v.Entry.
+//
+// Flags:
+//
+//	-type    comma separated struct types, default all structs with valgo rules
+//	-output  output file, default <file>_valgo.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrbns/valgo/lib/valgen"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated struct types, default all structs with valgo rules")
+	output := flag.String("output", "", "output file, default <file>_valgo.go")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: valgen [-type T1,T2] [-output file] [file.go]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	filename := os.Getenv("GOFILE")
+	if flag.NArg() > 0 {
+		filename = flag.Arg(0)
+	}
+	if filename == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(filename, *typeNames, *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(filename, typeNames, output string) error {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	var types []string
+	if typeNames != "" {
+		types = strings.Split(typeNames, ",")
+	}
+
+	code, err := valgen.Generate(filename, src, types...)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		output = strings.TrimSuffix(filename, filepath.Ext(filename)) + "_valgo.go"
+	}
+	return os.WriteFile(output, code, 0o644)
+}
@@ -0,0 +1,81 @@
+package v
+
+// action implements Action[T] on top of a predicate and a description.
+// All built-in string, int, float and time actions are actions.
+type action[T any] struct {
+	validate func(v T) bool
+	// describe returns the code, the default error message, the params and
+	// the JSON Schema keywords. It is only called when the action fails or is exported,
+	// so building an action doesn't pay for them.
+	describe func() actionInfo
+	// options customize the error message, see [ErrMsg].
+	options []ActionOptionFace
+}
+
+// actionInfo describes an action.
+type actionInfo struct {
+	// code identifies the failure in the returned [ActionError].
+	code string
+	// msg is the default error message.
+	msg string
+	// params are the arguments of the action, reported in the [ActionError].
+	params map[string]any
+	// schema holds the JSON Schema keywords of the action, nil for custom actions.
+	schema JSONSchema
+	// required marks actions that reject the zero value, such as [NotEmpty].
+	required bool
+}
+
+// Run executes the validation function on the given value.
+// Returns an [ActionError] if validation fails.
+func (action *action[T]) Run(value T) error {
+	if !action.validate(value) {
+		info := action.describe()
+		msg, custom := extractMsg(info.msg, value, action.options...)
+		return newActionError(info.code, msg, info.params, value, custom)
+	}
+	return nil
+}
+
+// NewAction creates a typed action from a predicate and a default error message.
+// The optional ActionOptions parameter can be used to customize the error message,
+// exactly like the built-in actions.
+//
+// Example:
+//
+//	func ValidCurrency(option ...v.ActionOptionFace) v.Action[Money] {
+//	    return v.NewAction("unsupported currency", func(m Money) bool {
+//	        return m.Currency == "EUR" || m.Currency == "USD"
+//	    }, option...)
+//	}
+func NewAction[T any](defaultMsg string, fn func(value T) bool, option ...ActionOptionFace) Action[T] {
+	return &action[T]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{msg: defaultMsg}
+		},
+		validate: fn,
+	}
+}
+
+// CustomAction creates a custom validator for any type using the provided validation function.
+// The optional ActionOptions parameter can be used to customize the error message.
+func CustomAction[T any](fn func(value T) bool, option ...ActionOptionFace) Action[T] {
+	return NewAction("invalid value", fn, option...)
+}
+
+// extractMsg extracts a custom error message from ActionOptions or returns the default message.
+// custom reports whether the message was set with [ErrMsg]. The message of ErrMsg is returned
+// as a template, its placeholders are filled with the others when the error is reported.
+func extractMsg(defaultMsg string, value any, option ...ActionOptionFace) (msg string, custom bool) {
+	msg = defaultMsg
+	for _, op := range option {
+		switch errInterface := op.(type) {
+		case *CustomErrMsg:
+			msg, custom = errInterface.msg, true
+		case CustomErrFace:
+			msg, custom = errInterface.Msg(value), true
+		}
+	}
+	return msg, custom
+}
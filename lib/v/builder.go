@@ -1,8 +1,9 @@
 package v
 
 import (
-	"strconv"
+	"slices"
 	"strings"
+	"time"
 )
 
 // PipeRegistry where all pipes will be compiled and saved.
@@ -12,6 +13,7 @@ type PipeRegistry struct {
 }
 
 // NewPipesBuilder creates a new [PipeSet] with the given pipes.
+// Cross-field rules such as [EqualField] can be passed along with the pipes.
 func NewPipesBuilder(pipeEntries ...PipeFace) PipeSet {
 	return &PipeRegistry{
 		pipes: pipeEntries,
@@ -22,23 +24,56 @@ func NewPipesBuilder(pipeEntries ...PipeFace) PipeSet {
 //
 // it validate all the pipes. but return the first error that pipe. but pipe will be ignored if there is no error.
 func (schema *PipeRegistry) ValidateAll() error {
-	return schema.validateAllSequential()
+	return schema.validateWith(runContext{all: true})
 }
 
-func (schema *PipeRegistry) ValidateAllParallel() error {
-	return schema.validateAllSequential()
+// ValidateAllParallel validates all the pipes concurrently with a bounded worker pool
+// and returns [ValidationErrors] in the same order as [PipeRegistry.ValidateAll].
+//
+// A panic inside an action is recovered and reported as a [PipeError] wrapping [ErrPanic].
+// Parallel validation only pays off when pipes do expensive work; for cheap built-in
+// actions the sequential path is faster.
+//
+// Example:
+//
+//	err := registry.ValidateAllParallel(v.Concurrency(4))
+func (schema *PipeRegistry) ValidateAllParallel(options ...ParallelOption) error {
+	return schema.validateParallelWith(runContext{all: true}, newParallelConfig(options))
+}
+
+func (schema *PipeRegistry) validateParallelWith(ctx runContext, cfg parallelConfig) error {
+	return validatePipesParallel(schema.pipes, ctx, cfg)
+}
+
+func (schema *PipeRegistry) Validate() error {
+	return schema.validateWith(runContext{})
 }
 
-func (schema *PipeRegistry) validateAllSequential() error {
+func (schema *PipeRegistry) validateWith(ctx runContext) error {
+	return validatePipes(schema.pipes, ctx)
+}
+
+// validatePipes validates the pipes of a pipe set in order. It stops at the first failure
+// unless ctx.all is set, in which case it returns all of them as [ValidationErrors].
+func validatePipes(pipes []PipeFace, ctx runContext) error {
+	return runPipes(pipes, ctx.withFields(pipes))
+}
+
+// runPipes is validatePipes for pipes that share the sibling fields of ctx,
+// such as the branch of a conditional pipe.
+func runPipes(pipes []PipeFace, ctx runContext) error {
 	var validationErrors ValidationErrors
 
-	for _, pipe := range schema.pipes {
-		if err := pipe.Validate(); err != nil {
-			if fieldErr, ok := err.(*PipeError); ok {
-				validationErrors = append(validationErrors, fieldErr)
-			} else {
-				validationErrors = append(validationErrors, NewPipeError(pipe.Key(), err))
+	for _, pipe := range pipes {
+		if ctx.skip(pipe) {
+			continue
+		}
+		if err := runPipe(pipe, ctx); err != nil {
+			errs := collectErrors(pipe.Key(), err)
+			if !ctx.all && len(errs) > 0 {
+				return errs[0]
 			}
+			validationErrors = append(validationErrors, errs...)
 		}
 	}
 
@@ -48,25 +83,81 @@ func (schema *PipeRegistry) validateAllSequential() error {
 	return nil
 }
 
-func (schema *PipeRegistry) Validate() error {
-	for _, pipe := range schema.pipes {
-		if err := pipe.Validate(); err != nil {
-			if fieldErr, ok := err.(*PipeError); ok {
-				return fieldErr
-			}
-			return NewPipeError(pipe.Key(), err)
-		}
+// MapOption configures how [NewPipesMap] orders the pipes of a [PipeMap].
+type MapOption func(*mapConfig)
+
+type mapConfig struct {
+	priority []string
+	compare  func(a, b string) int
+	rules    []PipeFace
+}
+
+// WithKeyOrder validates the given keys first, in the given order.
+// Keys that are not listed follow in sorted order.
+//
+// Example:
+//
+//	v.NewPipesMap(pipeMap, v.WithKeyOrder("email", "password"))
+func WithKeyOrder(keys ...string) MapOption {
+	return func(c *mapConfig) {
+		c.priority = keys
+	}
+}
+
+// WithKeySort orders the keys that are not listed in [WithKeyOrder] with cmp
+// instead of the default ascending sort. cmp follows the [slices.SortFunc] contract.
+func WithKeySort(cmp func(a, b string) int) MapOption {
+	return func(c *mapConfig) {
+		c.compare = cmp
+	}
+}
+
+// WithRules adds cross-field rules such as [EqualField] or [AtLeastOneOf].
+// They are validated after the pipes of the map.
+//
+// Example:
+//
+//	v.NewPipesMap(pipeMap, v.WithRules(
+//	    v.EqualField("password_confirm", "password"),
+//	    v.AtLeastOneOf([]string{"email", "phone"}),
+//	))
+func WithRules(rules ...PipeFace) MapOption {
+	return func(c *mapConfig) {
+		c.rules = append(c.rules, rules...)
 	}
-	return nil
 }
 
 // NewPipesMap creates a new [PipeSet] from a [PipeMap].
-func NewPipesMap(pipeMap PipeMap) PipeSet {
+//
+// Pipes are validated in sorted key order unless options say otherwise, so
+// Validate reports the same first failure and ValidateAll the same error order
+// on every run. For declaration order use [NewPipesBuilder] with [Entry].
+func NewPipesMap(pipeMap PipeMap, options ...MapOption) PipeSet {
+	cfg := mapConfig{compare: strings.Compare}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	rest := make([]string, 0, len(pipeMap))
+	for k := range pipeMap {
+		if !slices.Contains(cfg.priority, k) {
+			rest = append(rest, k)
+		}
+	}
+	slices.SortStableFunc(rest, cfg.compare)
+
 	pipes := make([]PipeFace, 0, len(pipeMap))
-	for k, v := range pipeMap {
+	seen := make(map[string]bool, len(pipeMap))
+	for _, k := range append(slices.Clone(cfg.priority), rest...) {
+		v, ok := pipeMap[k]
+		if !ok || seen[k] {
+			continue
+		}
+		seen[k] = true
 		v.setKey(k)
 		pipes = append(pipes, v)
 	}
+	pipes = append(pipes, cfg.rules...)
 	return &PipeRegistry{
 		pipes: pipes,
 	}
@@ -86,29 +177,32 @@ func Entry(key string) *PipeEntryKeyHolder {
 
 // StringPipe Creates a String Pipe
 func (pk *PipeEntryKeyHolder) StringPipe(value string, actions ...StringPipeAction) PipeFace {
-	return &stringPipeManager{
-		value:   value,
-		actions: actions,
-		error:   nil,
-	}
+	return pk.Pipe(StringPipe(value, actions...))
 }
 
 // IntPipe Creates a Int Pipe
 func (pk *PipeEntryKeyHolder) IntPipe(value int, actions ...IntPipeAction) PipeFace {
-	return &IntPipeManager{
-		value:   value,
-		actions: actions,
-		error:   nil,
-	}
+	return pk.Pipe(IntPipe(value, actions...))
 }
 
 // FloatPipe Creates a Float Pipe
 func (pk *PipeEntryKeyHolder) FloatPipe(value float64, actions ...FloatPipeAction) PipeFace {
-	return &floatPipeManager{
-		value:   value,
-		actions: actions,
-		error:   nil,
-	}
+	return pk.Pipe(FloatPipe(value, actions...))
+}
+
+// TimePipe Creates a Time Pipe
+func (pk *PipeEntryKeyHolder) TimePipe(value time.Time, actions ...TimePipeAction) PipeFace {
+	return pk.Pipe(TimePipe(value, actions...))
+}
+
+// Pipe attaches the entry key to any pipe, including a generic [Pipe] built with [NewPipe].
+//
+// Example:
+//
+//	v.Entry("price").Pipe(v.NewPipe(price, PositiveAmount()))
+func (pk *PipeEntryKeyHolder) Pipe(pipe PipeFace) PipeFace {
+	pipe.setKey(pk.key)
+	return pipe
 }
 
 // Custom Error message
@@ -117,57 +211,34 @@ type CustomErrMsg struct {
 }
 
 // A getter to Get Error message.
+// It fills the {VALUE} and {LEN} placeholders of the message; see [ErrMsg].
 func (c *CustomErrMsg) Msg(v any) string {
-
-	msg := c.msg
-
-	if !strings.Contains(msg, "{VALUE}") {
-		return msg
-	}
-
-	value := ""
-
-	switch val := v.(type) {
-	case string:
-		value = val
-	case int:
-		value = strconv.Itoa(val)
-	case int8:
-		value = strconv.FormatInt(int64(val), 10)
-	case int16:
-		value = strconv.FormatInt(int64(val), 10)
-	case int32:
-		value = strconv.FormatInt(int64(val), 10)
-	case int64:
-		value = strconv.FormatInt(val, 10)
-	case uint:
-		value = strconv.FormatUint(uint64(val), 10)
-	case uint8:
-		value = strconv.FormatUint(uint64(val), 10)
-	case uint16:
-		value = strconv.FormatUint(uint64(val), 10)
-	case uint32:
-		value = strconv.FormatUint(uint64(val), 10)
-	case uint64:
-		value = strconv.FormatUint(val, 10)
-	case float32:
-		value = strconv.FormatFloat(float64(val), 'f', -1, 32)
-	case float64:
-		value = strconv.FormatFloat(val, 'f', -1, 64)
-	case bool:
-		value = strconv.FormatBool(val)
-	default:
-		return msg
-	}
-
-	return strings.Replace(msg, "{VALUE}", value, -1)
+	return renderTemplate(c.msg, valueLookup(v))
 }
 
 func (c *CustomErrMsg) Run(v any) error {
 	return nil
 }
 
-// ErrMsg is used to specify custom error message
+// ErrMsg is used to specify custom error message.
+// The message is a template; a placeholder is a name followed by optional filters:
+//
+//	{KEY}           the key of the field, e.g. address.city
+//	{VALUE}         the rejected value; times are formatted as RFC 3339
+//	{LEN}           the length of the value in characters, or items for slices and maps
+//	{PARAM.min}     a param of the failed action, see [ActionError]
+//
+//	|date:layout    formats a time with a Go layout, e.g. {VALUE|date:2006-01-02}
+//	|truncate:N     keeps the first N characters, followed by …
+//	|mask           hides the value, e.g. for passwords
+//	|mask:N         hides all but the last N characters, e.g. {VALUE|mask:4} for card numbers
+//
+// Placeholders with an unknown name or filter are left as they are.
+// The messages of a [Catalog] are templates too.
+//
+// Example:
+//
+//	v.MaxLength(20, v.ErrMsg("{VALUE|truncate:10} is longer than {PARAM.max} characters"))
 func ErrMsg(v string) CustomErrFace {
 	return &CustomErrMsg{
 		msg: v,
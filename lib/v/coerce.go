@@ -0,0 +1,161 @@
+package v
+
+import (
+	"strconv"
+	"time"
+)
+
+// CoercePipe validates a string input, e.g. a query parameter, form value or
+// environment variable, by parsing it into a T and running the actions of T
+// on the parsed value. A string that does not parse fails with a [PipeError].
+type CoercePipe[T any] struct {
+	input   string
+	parse   func(s string) (T, error)
+	actions []Action[T]
+	plain   bool
+	key     string
+	target  *T
+}
+
+// Coerce creates a pipe that parses input with parse and then runs actions on the result.
+// The error returned by parse is reported as the validation error.
+//
+// Example:
+//
+//	port := v.Coerce(os.Getenv("PORT"), func(s string) (uint16, error) {
+//	    n, err := strconv.ParseUint(s, 10, 16)
+//	    if err != nil {
+//	        return 0, errors.New("must be a port number")
+//	    }
+//	    return uint16(n), nil
+//	})
+func Coerce[T any](input string, parse func(s string) (T, error), actions ...Action[T]) *CoercePipe[T] {
+	return &CoercePipe[T]{input: input, parse: parse, actions: actions, plain: plainActions(actions)}
+}
+
+// CoerceInt parses a base 10 integer and runs the int actions on it.
+//
+// Example:
+//
+//	page := v.CoerceInt(r.URL.Query().Get("page"), v.Min(1), v.Max(100))
+//	if err := page.Validate(); err == nil {
+//	    fetch(page.Value())
+//	}
+func CoerceInt(input string, actions ...IntPipeAction) *CoercePipe[int] {
+	return Coerce(input, func(s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, parseError("int.parse", "must be an integer", s, nil)
+		}
+		return n, nil
+	}, actions...)
+}
+
+// CoerceFloat parses a floating point number and runs the float actions on it.
+func CoerceFloat(input string, actions ...FloatPipeAction) *CoercePipe[float64] {
+	return Coerce(input, func(s string) (float64, error) {
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, parseError("float.parse", "must be a number", s, nil)
+		}
+		return n, nil
+	}, actions...)
+}
+
+// CoerceBool parses a boolean as accepted by strconv.ParseBool:
+// 1, t, T, TRUE, true, True, 0, f, F, FALSE, false and False.
+func CoerceBool(input string, actions ...Action[bool]) *CoercePipe[bool] {
+	return Coerce(input, func(s string) (bool, error) {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return false, parseError("bool.parse", "must be a boolean", s, nil)
+		}
+		return b, nil
+	}, actions...)
+}
+
+// CoerceTime parses a time in the given layout and runs the time actions on it.
+//
+// Example:
+//
+//	since := v.CoerceTime(q.Get("since"), time.DateOnly, v.Before(time.Now()))
+func CoerceTime(input, layout string, actions ...TimePipeAction) *CoercePipe[time.Time] {
+	return Coerce(input, func(s string) (time.Time, error) {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return time.Time{}, parseError("time.parse", "must be a time in the format "+layout, s, map[string]any{"layout": layout})
+		}
+		return t, nil
+	}, actions...)
+}
+
+// CoerceDuration parses a duration such as "300ms" or "1h30m" and runs the actions on it.
+func CoerceDuration(input string, actions ...Action[time.Duration]) *CoercePipe[time.Duration] {
+	return Coerce(input, func(s string) (time.Duration, error) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, parseError("duration.parse", "must be a duration", s, nil)
+		}
+		return d, nil
+	}, actions...)
+}
+
+// parseError returns the failure of a built-in coercion on input.
+func parseError(code string, msg string, input string, params map[string]any) error {
+	return &ActionError{Code: code, Msg: msg, Params: params, Value: input}
+}
+
+// setKey sets the validation key for this pipe.
+// This key is used in error messages to identify which field failed validation.
+func (pipe *CoercePipe[T]) setKey(k string) {
+	pipe.key = k
+}
+
+// Key returns the validation key associated with this pipe.
+func (pipe *CoercePipe[T]) Key() string {
+	return pipe.key
+}
+
+// Value returns the parsed value, rewritten by transform actions, or the
+// zero value of T when the input does not parse.
+func (pipe *CoercePipe[T]) Value() T {
+	value, err := pipe.parse(pipe.input)
+	if err != nil {
+		var zero T
+		return zero
+	}
+	return NewPipe(value, pipe.actions...).Value()
+}
+
+// Bind writes the parsed value into target once the pipe passes.
+//
+// Example:
+//
+//	"limit": v.CoerceInt(q.Get("limit"), v.Max(100)).Bind(&req.Limit),
+func (pipe *CoercePipe[T]) Bind(target *T) *CoercePipe[T] {
+	pipe.target = target
+	return pipe
+}
+
+// Validate parses the input and runs all validation actions in sequence.
+func (pipe *CoercePipe[T]) Validate() error {
+	return pipe.validateWith(runContext{})
+}
+
+func (pipe *CoercePipe[T]) validateWith(ctx runContext) error {
+	value, err := pipe.parse(pipe.input)
+	if err != nil {
+		return NewPipeError(pipe.key, err)
+	}
+	inner := Pipe[T]{key: pipe.key, value: value, actions: pipe.actions, plain: pipe.plain, target: pipe.target}
+	return inner.validateWith(ctx)
+}
+
+// fieldValue exposes the parsed value to cross-field rules.
+func (pipe *CoercePipe[T]) fieldValue() (any, bool) {
+	if _, err := pipe.parse(pipe.input); err != nil {
+		return nil, false
+	}
+	value := pipe.Value()
+	return value, !isZeroValue(value)
+}
@@ -0,0 +1,227 @@
+package v
+
+import "strings"
+
+// combinedError reports the failures of the alternatives of a combinator.
+// errors.Is and errors.As look through it into every alternative.
+type combinedError struct {
+	msg  string
+	errs []error
+}
+
+func (e *combinedError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return e.msg + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *combinedError) Unwrap() []error {
+	return e.errs
+}
+
+// combinator runs all of its actions and decides from the failures whether the value passes.
+type combinator[T any] struct {
+	// keyword is the JSON Schema keyword combining the schemas of the actions.
+	keyword string
+	actions []Action[T]
+	// check gets the number of actions that ran, transforms excluded.
+	check func(value T, ran int, failed []error) error
+}
+
+// newCombinator returns a combinator of actions. When one of them transforms
+// the value or validates nested values, such as [Each], it runs them the way
+// a [Pipe] does.
+func newCombinator[T any](keyword string, actions []Action[T], check func(value T, ran int, failed []error) error) Action[T] {
+	c := &combinator[T]{keyword: keyword, actions: actions, check: check}
+	if plainActions(actions) {
+		return c
+	}
+	return &contextCombinator[T]{c}
+}
+
+// Run runs every action on value and checks the failures.
+func (c *combinator[T]) Run(value T) error {
+	var failed []error
+	for _, action := range c.actions {
+		if err := action.Run(value); err != nil {
+			failed = append(failed, err)
+		}
+	}
+	return c.check(value, len(c.actions), failed)
+}
+
+// contextCombinator is a combinator whose actions need the run settings.
+// A transform rewrites the value the actions after it see.
+type contextCombinator[T any] struct {
+	*combinator[T]
+}
+
+// Run runs every action on value and checks the failures.
+func (c *contextCombinator[T]) Run(value T) error {
+	return c.runWith(value, runContext{})
+}
+
+func (c *contextCombinator[T]) runWith(value T, ctx runContext) error {
+	var failed []error
+	ran := 0
+	for _, action := range c.actions {
+		if t, ok := action.(transformer[T]); ok {
+			value = t.transform(value)
+			continue
+		}
+		ran++
+		if err := runAction(action, value, ctx); err != nil {
+			failed = append(failed, err)
+		}
+	}
+	return c.check(value, ran, failed)
+}
+
+// AnyOf passes when at least one of the actions passes.
+// Otherwise the error lists why every alternative failed.
+// Without actions it always fails.
+//
+// Example:
+//
+//	v.StringPipe(id, v.AnyOf(v.IsUUID(), v.IsULID()))
+//	// not a valid value, any of: not a valid UUID; not a valid ULID
+func AnyOf[T any](actions ...Action[T]) Action[T] {
+	return newCombinator("anyOf", actions, func(value T, ran int, failed []error) error {
+		switch {
+		case ran == 0:
+			return noAlternatives(value)
+		case len(failed) < ran:
+			return nil
+		}
+		return &combinedError{msg: "not a valid value, any of", errs: failed}
+	})
+}
+
+// AllOf passes when every action passes. Unlike a plain action list it runs
+// all of them and lists every failure, not only the first one.
+func AllOf[T any](actions ...Action[T]) Action[T] {
+	return newCombinator("allOf", actions, func(value T, ran int, failed []error) error {
+		if len(failed) == 0 {
+			return nil
+		}
+		return &combinedError{msg: "not a valid value, all of", errs: failed}
+	})
+}
+
+// ExactlyOneOf passes when exactly one of the actions passes.
+// When none pass the error lists every failure, when several pass it fails with
+// the code combinator.one_of and the number of matches as the matched param.
+// Without actions it always fails.
+func ExactlyOneOf[T any](actions ...Action[T]) Action[T] {
+	return newCombinator("oneOf", actions, func(value T, ran int, failed []error) error {
+		switch passed := ran - len(failed); {
+		case ran == 0:
+			return noAlternatives(value)
+		case passed == 1:
+			return nil
+		case passed == 0:
+			return &combinedError{msg: "not a valid value, exactly one of", errs: failed}
+		default:
+			params := map[string]any{"matched": passed}
+			return newActionError("combinator.one_of", "must match exactly one alternative, matched {PARAM.matched}", params, value, false)
+		}
+	})
+}
+
+// noAlternatives returns the failure of an [AnyOf] or [ExactlyOneOf] without actions.
+func noAlternatives(value any) error {
+	return newActionError("combinator.empty", "no alternatives to match", nil, value, false)
+}
+
+// Not passes when the inner action fails.
+// The optional ActionOptions parameter can be used to customize the error message.
+//
+// Example:
+//
+//	v.StringPipe(host, v.Not(v.IsIPV4(), v.ErrMsg("use a host name, not an IP address")))
+func Not[T any](inner Action[T], option ...ActionOptionFace) Action[T] {
+	return &action[T]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				msg:    "value is not allowed",
+				schema: JSONSchema{"not": ActionJSONSchema(inner)},
+			}
+		},
+		validate: func(v T) bool {
+			return inner.Run(v) != nil
+		},
+	}
+}
+
+// WithMsg replaces the error message of action, e.g. the aggregated message of a combinator.
+// The replaced error still wraps the original one for errors.Is and errors.As.
+//
+// Example:
+//
+//	v.WithMsg(v.AnyOf(v.IsUUID(), v.IsULID()), v.ErrMsg("must be a UUID or ULID"))
+func WithMsg[T any](action Action[T], option ...ActionOptionFace) Action[T] {
+	return &messageAction[T]{action: action, option: option}
+}
+
+// messageAction replaces the error message of the wrapped action.
+type messageAction[T any] struct {
+	action Action[T]
+	option []ActionOptionFace
+}
+
+// Run runs the wrapped action and replaces the message of its error.
+func (m *messageAction[T]) Run(value T) error {
+	err := m.action.Run(value)
+	if err == nil {
+		return nil
+	}
+	msg, custom := extractMsg("", value, m.option...)
+	if !custom {
+		return err
+	}
+	var params map[string]any
+	if actionErr := actionErrorOf(err); actionErr != nil {
+		params = actionErr.Params
+	}
+	return newMessageError(err, msg, value, params, true)
+}
+
+// messageError replaces the message of err while keeping it in the chain.
+type messageError struct {
+	msg string
+	err error
+	// custom marks a message set with [ErrMsg].
+	custom bool
+	// template, value and params render the message with {KEY}, see [PipeError].
+	template string
+	value    any
+	params   map[string]any
+}
+
+// newMessageError replaces the message of err with the template tmpl.
+// {KEY} is filled by the [PipeError] reporting it.
+func newMessageError(err error, tmpl string, value any, params map[string]any, custom bool) *messageError {
+	return &messageError{
+		msg:      renderTemplate(tmpl, errorLookup(value, params)),
+		err:      err,
+		custom:   custom,
+		template: tmpl,
+		value:    value,
+		params:   params,
+	}
+}
+
+func (e *messageError) messageFor(key string) string {
+	return renderTemplate(e.template, errorLookup(e.value, e.params, key))
+}
+
+func (e *messageError) Error() string {
+	return e.msg
+}
+
+func (e *messageError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,145 @@
+package v
+
+import "time"
+
+// SchemaField is a field of a [CompiledSchema] for values of type T.
+// It reads its value through an accessor when the schema is validated.
+type SchemaField[T any] interface {
+	Key() string
+	validateField(p *T, ctx runContext) error
+}
+
+// CompiledSchema validates values of type T against rules defined once.
+// Unlike [Schema.Rules], which builds every pipe and action again for each
+// value, the actions of a compiled schema are built by [Compile] and reused.
+// A CompiledSchema is safe for concurrent use as long as its actions are,
+// which all built-in actions are.
+//
+// Example:
+//
+//	var userSchema = v.Compile(
+//	    v.StringField("email", func(u *User) string { return u.Email }, v.NotEmpty(), v.IsEmail()),
+//	    v.IntField("age", func(u *User) int { return u.Age }, v.Min(18)),
+//	)
+//
+//	func handler(u *User) error {
+//	    return userSchema.ValidateAll(u)
+//	}
+type CompiledSchema[T any] struct {
+	fields []SchemaField[T]
+}
+
+// Compile creates a schema from its fields. Fields are validated in the given order.
+func Compile[T any](fields ...SchemaField[T]) *CompiledSchema[T] {
+	return &CompiledSchema[T]{fields: fields}
+}
+
+// Validate validates p and returns the first error.
+func (s *CompiledSchema[T]) Validate(p *T) error {
+	return s.validate(p, runContext{})
+}
+
+// ValidateAll validates p and returns all errors as [ValidationErrors].
+func (s *CompiledSchema[T]) ValidateAll(p *T) error {
+	return s.validate(p, runContext{all: true})
+}
+
+func (s *CompiledSchema[T]) validate(p *T, ctx runContext) error {
+	var validationErrors ValidationErrors
+
+	for _, field := range s.fields {
+		err := field.validateField(p, ctx)
+		if err == nil {
+			continue
+		}
+		if !ctx.all {
+			return err
+		}
+		validationErrors = append(validationErrors, collectErrors(field.Key(), err)...)
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+// accessorField validates the value returned by get with a list of actions.
+type accessorField[T, V any] struct {
+	key     string
+	get     func(p *T) V
+	actions []Action[V]
+	plain   bool
+}
+
+// Field creates a field of a [CompiledSchema] that validates the value returned by get.
+//
+// Example:
+//
+//	v.Field("price", func(o *Order) Money { return o.Price }, PositiveAmount())
+func Field[T, V any](key string, get func(p *T) V, actions ...Action[V]) SchemaField[T] {
+	return &accessorField[T, V]{key: key, get: get, actions: actions, plain: plainActions(actions)}
+}
+
+// StringField creates a field of a [CompiledSchema] for a string value.
+func StringField[T any](key string, get func(p *T) string, actions ...StringPipeAction) SchemaField[T] {
+	return Field(key, get, actions...)
+}
+
+// IntField creates a field of a [CompiledSchema] for an int value.
+func IntField[T any](key string, get func(p *T) int, actions ...IntPipeAction) SchemaField[T] {
+	return Field(key, get, actions...)
+}
+
+// FloatField creates a field of a [CompiledSchema] for a float64 value.
+func FloatField[T any](key string, get func(p *T) float64, actions ...FloatPipeAction) SchemaField[T] {
+	return Field(key, get, actions...)
+}
+
+// TimeField creates a field of a [CompiledSchema] for a time.Time value.
+func TimeField[T any](key string, get func(p *T) time.Time, actions ...TimePipeAction) SchemaField[T] {
+	return Field(key, get, actions...)
+}
+
+// Key returns the validation key of the field.
+func (f *accessorField[T, V]) Key() string {
+	return f.key
+}
+
+func (f *accessorField[T, V]) validateField(p *T, ctx runContext) error {
+	pipe := Pipe[V]{key: f.key, value: f.get(p), actions: f.actions, plain: f.plain}
+	return pipe.validateWith(ctx)
+}
+
+// checkField validates a whole value with a function, e.g. to compare fields.
+type checkField[T any] struct {
+	key   string
+	check func(p *T) error
+}
+
+// Check creates a field of a [CompiledSchema] whose error, if any, is reported under key.
+// Use it for rules spanning several fields.
+//
+// Example:
+//
+//	v.Check("password_confirm", func(s *Signup) error {
+//	    if s.PasswordConfirm != s.Password {
+//	        return errors.New("must match password")
+//	    }
+//	    return nil
+//	})
+func Check[T any](key string, check func(p *T) error) SchemaField[T] {
+	return &checkField[T]{key: key, check: check}
+}
+
+// Key returns the validation key of the field.
+func (f *checkField[T]) Key() string {
+	return f.key
+}
+
+func (f *checkField[T]) validateField(p *T, ctx runContext) error {
+	if err := f.check(p); err != nil {
+		return NewPipeError(f.key, err)
+	}
+	return nil
+}
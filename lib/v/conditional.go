@@ -0,0 +1,175 @@
+package v
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Condition decides whether the pipes of a conditional branch run.
+// It reads the values of the sibling pipes in the same [PipeSet].
+//
+// Example:
+//
+//	isCompany := func(f v.Fields) bool {
+//	    kind, _ := f.Value("type")
+//	    return kind == "company"
+//	}
+type Condition func(f Fields) bool
+
+// FieldEquals is a [Condition] that holds when the field key equals value.
+func FieldEquals(key string, value any) Condition {
+	return func(f Fields) bool {
+		got, ok := f.Value(key)
+		return ok && sameValue(got, value)
+	}
+}
+
+// FieldIn is a [Condition] that holds when the field key equals one of values.
+func FieldIn(key string, values ...any) Condition {
+	return func(f Fields) bool {
+		got, ok := f.Value(key)
+		if !ok {
+			return false
+		}
+		return slices.ContainsFunc(values, func(value any) bool {
+			return sameValue(got, value)
+		})
+	}
+}
+
+// FieldSet is a [Condition] that holds when the field key is set, see [Fields.IsSet].
+func FieldSet(key string) Condition {
+	return func(f Fields) bool {
+		return f.IsSet(key)
+	}
+}
+
+// Cases maps the values of a [Switch] field to the pipes to run for them.
+type Cases map[any][]PipeFace
+
+// conditionalPipe runs the branch of pipes chosen from the sibling field values.
+// Errors of the branch are keyed and ordered exactly like unconditional pipes.
+type conditionalPipe struct {
+	key      string
+	branch   func(f Fields) []PipeFace
+	children []PipeFace
+	// switchCases is set by [Switch], whose branches, unlike the
+	// conditions of [When] and [Unless], can be exported as JSON Schema.
+	switchCases *switchCases
+}
+
+// switchCases are the branches of a [Switch] on the field key.
+type switchCases struct {
+	key       string
+	cases     Cases
+	caseKeys  []any
+	otherwise []PipeFace
+}
+
+// When runs pipes only if cond holds.
+//
+// In a [PipeMap] the pipes without a key of their own are reported under the map key:
+//
+//	"zip": v.When(v.FieldEquals("country", "US"), v.StringPipe(a.Zip, v.Pattern(`^\d{5}$`))),
+//
+// In [NewPipesBuilder] give every pipe its [Entry]:
+//
+//	v.When(v.FieldEquals("type", "company"), v.Entry("vat_id").StringPipe(c.VatID, v.NotEmpty())),
+func When(cond Condition, pipes ...PipeFace) PipeFace {
+	return &conditionalPipe{
+		branch: func(f Fields) []PipeFace {
+			if cond(f) {
+				return pipes
+			}
+			return nil
+		},
+		children: pipes,
+	}
+}
+
+// Unless runs pipes only if cond does not hold.
+func Unless(cond Condition, pipes ...PipeFace) PipeFace {
+	return &conditionalPipe{
+		branch: func(f Fields) []PipeFace {
+			if !cond(f) {
+				return pipes
+			}
+			return nil
+		},
+		children: pipes,
+	}
+}
+
+// Switch runs the pipes of the case matching the value of the field key,
+// or the otherwise pipes when no case matches.
+//
+// Example:
+//
+//	"postal_code": v.Switch("country", v.Cases{
+//	    "US": {v.StringPipe(a.PostalCode, v.Pattern(`^\d{5}(-\d{4})?$`))},
+//	    "BD": {v.StringPipe(a.PostalCode, v.Pattern(`^\d{4}$`))},
+//	}, v.StringPipe(a.PostalCode, v.NotEmpty())),
+func Switch(key string, cases Cases, otherwise ...PipeFace) PipeFace {
+	children := slices.Clone(otherwise)
+	for _, pipes := range cases {
+		children = append(children, pipes...)
+	}
+
+	// cases are matched in a fixed order, so the same field value
+	// always selects the same branch.
+	caseKeys := slices.SortedFunc(maps.Keys(cases), func(a, b any) int {
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+
+	return &conditionalPipe{
+		branch: func(f Fields) []PipeFace {
+			value, ok := f.Value(key)
+			if !ok {
+				return otherwise
+			}
+			for _, caseKey := range caseKeys {
+				if sameValue(value, caseKey) {
+					return cases[caseKey]
+				}
+			}
+			return otherwise
+		},
+		children:    children,
+		switchCases: &switchCases{key: key, cases: cases, caseKeys: caseKeys, otherwise: otherwise},
+	}
+}
+
+// setKey sets the validation key for this pipe and for the pipes of its
+// branches that have no key of their own.
+func (pipe *conditionalPipe) setKey(k string) {
+	for _, child := range pipe.children {
+		if child.Key() == "" || child.Key() == pipe.key {
+			child.setKey(k)
+		}
+	}
+	pipe.key = k
+}
+
+// Key returns the validation key associated with this pipe.
+func (pipe *conditionalPipe) Key() string {
+	return pipe.key
+}
+
+// Validate runs the branch chosen without sibling pipes, so every field is
+// missing: [FieldSet], [FieldEquals] and [FieldIn] are false and [Switch]
+// runs its otherwise pipes. Add the pipe to a [PipeMap], [NewPipesMap] or
+// [NewPipesBuilder] for its conditions to see the other fields.
+func (pipe *conditionalPipe) Validate() error {
+	return pipe.validateWith(runContext{})
+}
+
+func (pipe *conditionalPipe) validateWith(ctx runContext) error {
+	return runPipes(pipe.branch(ctx.fields), ctx)
+}
+
+// childPipes returns the pipes of all branches.
+func (pipe *conditionalPipe) childPipes() []PipeFace {
+	return pipe.children
+}
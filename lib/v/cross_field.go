@@ -0,0 +1,184 @@
+package v
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fieldRule is a cross-field rule. It validates the values of sibling pipes
+// in the same [PipeSet] and reports its failures on its own keys.
+type fieldRule struct {
+	key   string
+	check func(f Fields) ValidationErrors
+}
+
+// setKey is a no-op: a cross-field rule reports on the keys it was built with.
+func (rule *fieldRule) setKey(string) {}
+
+// Key returns the key the rule is attached to.
+func (rule *fieldRule) Key() string {
+	return rule.key
+}
+
+// Validate fails because a cross-field rule needs its sibling pipes.
+// Add the rule to [NewPipesBuilder] or [NewPipesMap] with [WithRules].
+func (rule *fieldRule) Validate() error {
+	return NewPipeError(rule.key, fmt.Errorf("cross-field rule must be validated within a PipeSet"))
+}
+
+func (rule *fieldRule) validateWith(ctx runContext) error {
+	errs := rule.check(ctx.fields)
+	if len(errs) == 0 {
+		return nil
+	}
+	if !ctx.all {
+		return errs[0]
+	}
+	return errs
+}
+
+// ruleError returns the error of a rule on key, an [ActionError] with the
+// code and params of the rule.
+func ruleError(key string, code string, defaultMsg string, params map[string]any, value any, option ...ActionOptionFace) ValidationErrors {
+	msg, custom := extractMsg(defaultMsg, value, option...)
+	return ValidationErrors{NewPipeError(key, newActionError(code, msg, params, value, custom))}
+}
+
+// EqualField validates that the field key is equal to the field other,
+// e.g. EqualField("password_confirm", "password"). The error is reported on key.
+// The optional ActionOptions parameter can be used to customize the error message.
+func EqualField(key string, other string, option ...ActionOptionFace) PipeFace {
+	return &fieldRule{
+		key: key,
+		check: func(f Fields) ValidationErrors {
+			value, _ := f.Value(key)
+			otherValue, _ := f.Value(other)
+			if equalValues(value, otherValue) {
+				return nil
+			}
+			return ruleError(key, "field.equal", "must be equal to "+other, map[string]any{"field": other}, value, option...)
+		},
+	}
+}
+
+// AfterField validates that the field key is strictly after (greater than) the
+// field other, e.g. AfterField("end_date", "start_date"). Times, numbers and strings
+// are supported. Nothing is checked while one of the fields is not set.
+// The optional ActionOptions parameter can be used to customize the error message.
+func AfterField(key string, other string, option ...ActionOptionFace) PipeFace {
+	return &fieldRule{
+		key: key,
+		check: func(f Fields) ValidationErrors {
+			if !f.IsSet(key) || !f.IsSet(other) {
+				return nil
+			}
+			value, _ := f.Value(key)
+			otherValue, _ := f.Value(other)
+			if result, comparable := compareValues(value, otherValue); comparable && result > 0 {
+				return nil
+			}
+			return ruleError(key, "field.after", "must be after "+other, map[string]any{"field": other}, value, option...)
+		},
+	}
+}
+
+// RequiredWith validates that the field key is set whenever any of others is set,
+// e.g. RequiredWith("city", []string{"street"}). The error wraps [ErrRequired]
+// and is reported on key.
+// The optional ActionOptions parameter can be used to customize the error message.
+func RequiredWith(key string, others []string, option ...ActionOptionFace) PipeFace {
+	return &fieldRule{
+		key: key,
+		check: func(f Fields) ValidationErrors {
+			if f.IsSet(key) {
+				return nil
+			}
+			for _, other := range others {
+				if f.IsSet(other) {
+					return requiredFieldError(f, key, "field.required_with", "when "+other+" is set", other, option...)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// RequiredWithout validates that the field key is set whenever any of others is not set.
+// The error wraps [ErrRequired] and is reported on key.
+// The optional ActionOptions parameter can be used to customize the error message.
+func RequiredWithout(key string, others []string, option ...ActionOptionFace) PipeFace {
+	return &fieldRule{
+		key: key,
+		check: func(f Fields) ValidationErrors {
+			if f.IsSet(key) {
+				return nil
+			}
+			for _, other := range others {
+				if !f.IsSet(other) {
+					return requiredFieldError(f, key, "field.required_without", "when "+other+" is not set", other, option...)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// requiredFieldError returns the error of a field required because of other.
+// It wraps [ErrRequired].
+func requiredFieldError(f Fields, key string, code string, reason string, other string, option ...ActionOptionFace) ValidationErrors {
+	value, _ := f.Value(key)
+	errs := ruleError(key, code, ErrRequired.Error()+" "+reason, map[string]any{"field": other}, value, option...)
+	actionErrorOf(errs[0]).cause = ErrRequired
+	return errs
+}
+
+// MutuallyExclusive validates that at most one of keys is set.
+// Every set field after the first one is reported.
+// The optional ActionOptions parameter can be used to customize the error message.
+func MutuallyExclusive(keys []string, option ...ActionOptionFace) PipeFace {
+	return &fieldRule{
+		key: firstKey(keys),
+		check: func(f Fields) ValidationErrors {
+			var errs ValidationErrors
+			first := ""
+			for _, key := range keys {
+				if !f.IsSet(key) {
+					continue
+				}
+				if first == "" {
+					first = key
+					continue
+				}
+				value, _ := f.Value(key)
+				errs = append(errs, ruleError(key, "field.exclusive", "cannot be set together with "+first, map[string]any{"field": first}, value, option...)...)
+			}
+			return errs
+		},
+	}
+}
+
+// AtLeastOneOf validates that at least one of keys is set,
+// e.g. AtLeastOneOf([]string{"email", "phone"}). The error is reported on the first key.
+// The optional ActionOptions parameter can be used to customize the error message.
+func AtLeastOneOf(keys []string, option ...ActionOptionFace) PipeFace {
+	return &fieldRule{
+		key: firstKey(keys),
+		check: func(f Fields) ValidationErrors {
+			for _, key := range keys {
+				if f.IsSet(key) {
+					return nil
+				}
+			}
+			value, _ := f.Value(firstKey(keys))
+			msg := "at least one of " + strings.Join(keys, ", ") + " is required"
+			return ruleError(firstKey(keys), "field.at_least_one", msg, map[string]any{"fields": keys}, value, option...)
+		},
+	}
+}
+
+func firstKey(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
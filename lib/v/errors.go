@@ -2,13 +2,83 @@ package v
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 )
 
+// ActionError is the error returned by a failed action. Code identifies the
+// failed rule and stays the same across releases and custom messages, e.g.
+// string.min_length, time.before or required; it is empty for actions built
+// with [NewAction] and [CustomAction]. Params holds the arguments of the rule,
+// e.g. {"min": 3}, and Value the rejected value.
+//
+// Example:
+//
+//	var actionErr *v.ActionError
+//	if errors.As(err, &actionErr) && actionErr.Code == "string.min_length" {
+//	    min := actionErr.Params["min"].(int)
+//	}
+type ActionError struct {
+	Code   string
+	Msg    string
+	Params map[string]any
+	Value  any
+	// cause is the sentinel the failure wraps, such as [ErrRequired].
+	cause error
+	// custom marks a message set with [ErrMsg].
+	custom bool
+	// template is the message before its placeholders were filled.
+	template string
+}
+
+// newActionError returns the failure of a rule on value with the message
+// template tmpl. {KEY} is filled by the [PipeError] reporting it.
+func newActionError(code string, tmpl string, params map[string]any, value any, custom bool) *ActionError {
+	return &ActionError{
+		Code:     code,
+		Msg:      renderTemplate(tmpl, errorLookup(value, params)),
+		Params:   params,
+		Value:    value,
+		custom:   custom,
+		template: tmpl,
+	}
+}
+
+func (e *ActionError) Error() string {
+	return e.Msg
+}
+
+func (e *ActionError) messageFor(key string) string {
+	if e.template == "" {
+		return e.Msg
+	}
+	return renderTemplate(e.template, errorLookup(e.Value, e.Params, key))
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.cause
+}
+
+// actionErrorOf returns the ActionError behind err. Errors joining several
+// failures, such as the error of [AnyOf], have no single code.
+func actionErrorOf(err error) *ActionError {
+	for err != nil {
+		if actionErr, ok := err.(*ActionError); ok {
+			return actionErr
+		}
+		err = errors.Unwrap(err)
+	}
+	return nil
+}
+
 // PipeError represents a validation error for a specific field.
+//
+// Key is the dotted rendering of Path, e.g. address.city for an error
+// reported by a nested schema.
 type PipeError struct {
-	Key string
-	Err error
+	Key  string
+	Path Path
+	Err  error
 }
 
 func NewPipeError(key string, err error) *PipeError {
@@ -16,14 +86,52 @@ func NewPipeError(key string, err error) *PipeError {
 	if err == nil {
 		return nil
 	}
-	return &PipeError{Key: key, Err: err}
+	return &PipeError{Key: key, Path: keyPath(key), Err: err}
+}
+
+// keyPath returns the single segment path of key, or nil for an empty key.
+func keyPath(key string) Path {
+	if key == "" {
+		return nil
+	}
+	return Path{PathKey(key)}
+}
+
+// setPath sets the path of the error and keeps Key in sync with it.
+func (e *PipeError) setPath(p Path) {
+	e.Path = p
+	e.Key = p.String()
+}
+
+// prefix places the error under the parent segments.
+func (e *PipeError) prefix(parent ...PathSegment) {
+	p := e.Path
+	if len(p) == 0 && e.Key != "" {
+		p = keyPath(e.Key)
+	}
+	e.setPath(append(append(Path{}, parent...), p...))
 }
 
 func (e *PipeError) Error() string {
 	if e.Key == "" {
-		return e.Err.Error()
+		return e.message()
 	}
-	return e.Key + ": " + e.Err.Error()
+	return e.Key + ": " + e.message()
+}
+
+// templateError is implemented by errors whose message is a template, such as
+// an [ErrMsg]. The [PipeError] reporting them renders it with {KEY} in the same
+// pass as the other placeholders, so filled in values are never scanned again.
+type templateError interface {
+	messageFor(key string) string
+}
+
+// message returns the message of Err with its {KEY} placeholders filled.
+func (e *PipeError) message() string {
+	if tmpl, ok := e.Err.(templateError); ok {
+		return tmpl.messageFor(e.Key)
+	}
+	return e.Err.Error()
 }
 
 func (e *PipeError) Unwrap() error {
@@ -31,11 +139,19 @@ func (e *PipeError) Unwrap() error {
 }
 
 // MarshalJSON ensures the underlying error string is serialized properly.
+// Errors of built-in actions also carry their code and params.
 func (e *PipeError) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]string{
+	m := map[string]any{
 		"key": e.Key,
-		"msg": e.Err.Error(),
-	})
+		"msg": e.message(),
+	}
+	if actionErr := actionErrorOf(e.Err); actionErr != nil && actionErr.Code != "" {
+		m["code"] = actionErr.Code
+		if len(actionErr.Params) > 0 {
+			m["params"] = actionErr.Params
+		}
+	}
+	return json.Marshal(m)
 }
 
 // ValidationErrors represents multiple validation errors.
@@ -64,6 +180,30 @@ func (v ValidationErrors) Unwrap() []error {
 	return errs
 }
 
+// DecodeError is the [ParseError.ParseError] of a payload field that can't be
+// decoded into the schema, such as a string sent for an int field. Path is the
+// location of the field, see [DecodeErrorPath]; Err the error of encoding/json.
+type DecodeError struct {
+	Path Path
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeError returns err as a [DecodeError] when it is about a field of target.
+func decodeError(err error, target any) error {
+	if path, ok := DecodeErrorPath(err, target); ok {
+		return &DecodeError{Path: path, Err: err}
+	}
+	return err
+}
+
 // ParseError wraps errors that occur during the parsing and validation lifecycle.
 type ParseError struct {
 	PreError        error `json:"-"`
@@ -0,0 +1,166 @@
+package v
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Fields gives cross-field rules and conditions read access to the values of
+// the sibling pipes in the same [PipeSet].
+type Fields struct {
+	pipes []PipeFace
+}
+
+// valuePipe is implemented by pipes that expose the value they validate.
+// set is false for a missing value such as a nil pointer or a zero value.
+type valuePipe interface {
+	fieldValue() (value any, set bool)
+}
+
+// groupPipe is implemented by pipes that hold sibling pipes of their own,
+// such as conditional pipes, so their values can be found too.
+type groupPipe interface {
+	childPipes() []PipeFace
+}
+
+// find returns the value of the sibling pipe with key.
+func (f Fields) find(key string) (value any, set bool, ok bool) {
+	return findField(f.pipes, key)
+}
+
+func findField(pipes []PipeFace, key string) (value any, set bool, ok bool) {
+	for _, pipe := range pipes {
+		if group, isGroup := pipe.(groupPipe); isGroup {
+			if value, set, ok = findField(group.childPipes(), key); ok {
+				return value, set, ok
+			}
+			continue
+		}
+		if pipe.Key() != key {
+			continue
+		}
+		if vp, isValue := pipe.(valuePipe); isValue {
+			value, set = vp.fieldValue()
+			return value, set, true
+		}
+	}
+	return nil, false, false
+}
+
+// Value returns the value validated by the sibling pipe with key.
+// ok is false if there is no such pipe or its value is missing, e.g. a nil pointer.
+func (f Fields) Value(key string) (value any, ok bool) {
+	value, _, found := f.find(key)
+	if !found || value == nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// IsSet reports whether the sibling pipe with key holds a non-zero value.
+// A non-nil pointer counts as set even if it points to a zero value.
+func (f Fields) IsSet(key string) bool {
+	_, set, _ := f.find(key)
+	return set
+}
+
+// fieldValue exposes the value of the pipe to cross-field rules.
+func (pipe *Pipe[T]) fieldValue() (any, bool) {
+	value := pipe.Value()
+	return value, !isZeroValue(value)
+}
+
+// fieldValue exposes the value behind the pointer to cross-field rules.
+func (pipe *pointerPipe[T]) fieldValue() (any, bool) {
+	if pipe.value == nil {
+		return nil, false
+	}
+	return *pipe.value, true
+}
+
+// fieldValue exposes the value of the wrapped pipe.
+func (p *presencePipe) fieldValue() (any, bool) {
+	return pipeValue(p.pipe)
+}
+
+// fieldValue exposes the value of the wrapped pipe.
+func (p *alwaysPipe) fieldValue() (any, bool) {
+	return pipeValue(p.pipe)
+}
+
+func pipeValue(pipe PipeFace) (any, bool) {
+	if vp, ok := pipe.(valuePipe); ok {
+		return vp.fieldValue()
+	}
+	return nil, false
+}
+
+// isZeroValue reports whether v is the zero value of its type.
+func isZeroValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+// equalValues reports whether a and b are equal, comparing times with [time.Time.Equal].
+func equalValues(a, b any) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// sameValue reports whether a and b are equal, also across number types,
+// so a condition on 1 matches a float64 field holding 1.0.
+func sameValue(a, b any) bool {
+	if equalValues(a, b) {
+		return true
+	}
+	result, ok := compareValues(a, b)
+	return ok && result == 0
+}
+
+// compareValues compares two times, strings or numbers of any kind.
+// ok is false if the values can't be ordered against each other.
+func compareValues(a, b any) (result int, ok bool) {
+	if ta, isTime := a.(time.Time); isTime {
+		tb, isTime := b.(time.Time)
+		if !isTime {
+			return 0, false
+		}
+		return ta.Compare(tb), true
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case va.Kind() == reflect.String && vb.Kind() == reflect.String:
+		return strings.Compare(va.String(), vb.String()), true
+	case isNumberKind(va.Kind()) && isNumberKind(vb.Kind()):
+		fa, fb := toFloat(va), toFloat(vb)
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
@@ -1,24 +1,5 @@
 package v
 
-import (
-	"fmt"
-)
-
-// floatAction implements FloatPipeAction for float64 validation.
-type floatAction struct {
-	errorMsg func(v float64) string
-	validate func(v float64) bool
-}
-
-// Run executes the validation function on the given float64 value.
-// Returns an error if validation fails.
-func (action *floatAction) Run(value float64) error {
-	if !action.validate(value) {
-		return fmt.Errorf("%s", action.errorMsg(value))
-	}
-	return nil
-}
-
 // CustomFloat creates a custom validator using the provided validation function.
 // The optional ActionOptions parameter can be used to customize the error message.
 //
@@ -26,9 +7,13 @@ func (action *floatAction) Run(value float64) error {
 //
 //	CustomFloat(func(v float64) bool { return v != 0 }, ErrMsg{msg: "value cannot be zero"})
 func CustomFloat(fn func(value float64) bool, option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("invalid float", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "float.custom",
+				msg:  "invalid float",
+			}
 		},
 		validate: fn,
 	}
@@ -41,9 +26,15 @@ func CustomFloat(fn func(value float64) bool, option ...ActionOptionFace) FloatP
 //
 //	GtFloat(5.0) // validates v > 5.0
 func GtFloat(value float64, option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("value must be greater than specified value", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "float.gt",
+				msg:    "value must be greater than specified value",
+				params: map[string]any{"value": value},
+				schema: JSONSchema{"exclusiveMinimum": value},
+			}
 		},
 		validate: func(v float64) bool {
 			return v > value
@@ -58,9 +49,15 @@ func GtFloat(value float64, option ...ActionOptionFace) FloatPipeAction {
 //
 //	GteFloat(5.0) // validates v >= 5.0
 func GteFloat(value float64, option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("value must be greater than or equal to specified value", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "float.gte",
+				msg:    "value must be greater than or equal to specified value",
+				params: map[string]any{"value": value},
+				schema: JSONSchema{"minimum": value},
+			}
 		},
 		validate: func(v float64) bool {
 			return v >= value
@@ -71,9 +68,14 @@ func GteFloat(value float64, option ...ActionOptionFace) FloatPipeAction {
 // IsNegativeFloat validates that a float64 value is less than zero.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsNegativeFloat(option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("value must be negative", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "float.negative",
+				msg:    "value must be negative",
+				schema: JSONSchema{"exclusiveMaximum": 0},
+			}
 		},
 		validate: func(v float64) bool {
 			return v < 0
@@ -84,9 +86,14 @@ func IsNegativeFloat(option ...ActionOptionFace) FloatPipeAction {
 // IsPositiveFloat validates that a float64 value is greater than or equal to zero.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsPositiveFloat(option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("value must be positive", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "float.positive",
+				msg:    "value must be positive",
+				schema: JSONSchema{"minimum": 0},
+			}
 		},
 		validate: func(v float64) bool {
 			return v >= 0
@@ -94,6 +101,25 @@ func IsPositiveFloat(option ...ActionOptionFace) FloatPipeAction {
 	}
 }
 
+// NonZeroFloat validates that a float64 value is not equal to zero.
+// The optional ActionOptions parameter can be used to customize the error message.
+func NonZeroFloat(option ...ActionOptionFace) FloatPipeAction {
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:     "float.non_zero",
+				msg:      "value must be non-zero",
+				schema:   JSONSchema{"not": JSONSchema{"const": 0}},
+				required: true,
+			}
+		},
+		validate: func(v float64) bool {
+			return v != 0
+		},
+	}
+}
+
 // LtFloat validates that a float64 value is strictly less than the specified value.
 // The optional ActionOptions parameter can be used to customize the error message.
 //
@@ -101,9 +127,15 @@ func IsPositiveFloat(option ...ActionOptionFace) FloatPipeAction {
 //
 //	LtFloat(10.0) // validates v < 10.0
 func LtFloat(value float64, option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("value must be less than specified value", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "float.lt",
+				msg:    "value must be less than specified value",
+				params: map[string]any{"value": value},
+				schema: JSONSchema{"exclusiveMaximum": value},
+			}
 		},
 		validate: func(v float64) bool {
 			return v < value
@@ -118,9 +150,15 @@ func LtFloat(value float64, option ...ActionOptionFace) FloatPipeAction {
 //
 //	LteFloat(10.0) // validates v <= 10.0
 func LteFloat(value float64, option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("value must be less than or equal to specified value", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "float.lte",
+				msg:    "value must be less than or equal to specified value",
+				params: map[string]any{"value": value},
+				schema: JSONSchema{"maximum": value},
+			}
 		},
 		validate: func(v float64) bool {
 			return v <= value
@@ -135,9 +173,15 @@ func LteFloat(value float64, option ...ActionOptionFace) FloatPipeAction {
 //
 //	MaxFloat(100.0) // validates v <= 100.0
 func MaxFloat(max float64, option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("value exceeds maximum", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "float.max",
+				msg:    "value exceeds maximum",
+				params: map[string]any{"max": max},
+				schema: JSONSchema{"maximum": max},
+			}
 		},
 		validate: func(v float64) bool {
 			return v <= max
@@ -153,9 +197,15 @@ func MaxFloat(max float64, option ...ActionOptionFace) FloatPipeAction {
 //	MinFloat(0.0) // validates v >= 0.0
 //	MinFloat(10.5, ErrMsg{msg: "custom error"})
 func MinFloat(min float64, option ...ActionOptionFace) FloatPipeAction {
-	return &floatAction{
-		errorMsg: func(v float64) string {
-			return extractMsg("value must be at least specified minimum", v, option...)
+	return &action[float64]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "float.min",
+				msg:    "value must be at least specified minimum",
+				params: map[string]any{"min": min},
+				schema: JSONSchema{"minimum": min},
+			}
 		},
 		validate: func(v float64) bool {
 			return v >= min
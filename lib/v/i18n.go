@@ -0,0 +1,321 @@
+package v
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Message is a catalog entry. Forms maps plural categories, such as
+// [PluralOne] and [PluralOther], to message templates; a message without
+// plural forms only has [PluralOther]. Count names the param the plural
+// category is chosen from, e.g. "min"; when empty, the only numeric param
+// of the error is used.
+//
+// In JSON a message is a string or an object of forms:
+//
+//	"string.email": "সঠিক ইমেইল নয়",
+//	"string.min_length": {"count": "min", "one": "at least {PARAM.min} character", "other": "at least {PARAM.min} characters"}
+type Message struct {
+	Count string
+	Forms map[string]string
+}
+
+// UnmarshalJSON reads a message from a string or an object of plural forms.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*m = Message{Forms: map[string]string{PluralOther: text}}
+		return nil
+	}
+
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("message must be a string or an object of plural forms")
+	}
+	count := forms["count"]
+	delete(forms, "count")
+	if _, ok := forms[PluralOther]; !ok {
+		return fmt.Errorf("plural message needs an %q form", PluralOther)
+	}
+	*m = Message{Count: count, Forms: forms}
+	return nil
+}
+
+// Catalog holds the messages of one locale keyed by error code, such as
+// string.min_length. Messages are templates, like those of [ErrMsg].
+type Catalog struct {
+	locale   string
+	messages map[string]Message
+}
+
+// NewCatalog creates a catalog of messages without plural forms.
+//
+// Example:
+//
+//	v.NewCatalog("bn", map[string]string{
+//	    "string.email": "সঠিক ইমেইল নয়",
+//	})
+func NewCatalog(locale string, messages map[string]string) *Catalog {
+	c := &Catalog{locale: locale, messages: make(map[string]Message, len(messages))}
+	for code, text := range messages {
+		c.messages[code] = Message{Forms: map[string]string{PluralOther: text}}
+	}
+	return c
+}
+
+// ParseCatalog reads a catalog from a JSON object mapping error codes to messages.
+func ParseCatalog(locale string, data []byte) (*Catalog, error) {
+	var messages map[string]Message
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("v: catalog %s: %w", locale, err)
+	}
+	if messages == nil {
+		// null decodes to a nil map
+		messages = map[string]Message{}
+	}
+	return &Catalog{locale: locale, messages: messages}, nil
+}
+
+// Locale returns the locale of the catalog, e.g. bn-BD.
+func (c *Catalog) Locale() string {
+	return c.locale
+}
+
+// Set adds or replaces the message of code.
+func (c *Catalog) Set(code string, message Message) *Catalog {
+	c.messages[code] = message
+	return c
+}
+
+// Translator renders the errors of the built-in actions from catalogs.
+// A locale falls back to its parent locales and then to the fallback locale,
+// e.g. bn-BD, bn, en. Errors without a message in any of them keep their
+// default English message.
+//
+// Messages set with [ErrMsg] or [WithMsg] are kept: the message of a single
+// call wins over the catalog.
+//
+// Example:
+//
+//	//go:embed locales/*.json
+//	var locales embed.FS
+//
+//	translator := v.NewTranslator("en")
+//	if err := translator.LoadFS(locales, "locales/*.json"); err != nil {
+//	    return err
+//	}
+//	err := translator.Locale("bn-BD").ValidateAll(&user)
+type Translator struct {
+	mu       sync.RWMutex
+	fallback string
+	catalogs map[string]*Catalog
+}
+
+// NewTranslator creates a translator with the given fallback locale and catalogs.
+func NewTranslator(fallback string, catalogs ...*Catalog) *Translator {
+	t := &Translator{fallback: fallback, catalogs: make(map[string]*Catalog)}
+	return t.Add(catalogs...)
+}
+
+// Add adds catalogs, replacing the catalogs of the same locales.
+func (t *Translator) Add(catalogs ...*Catalog) *Translator {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, c := range catalogs {
+		t.catalogs[normalizeLocale(c.locale)] = c
+	}
+	return t
+}
+
+// LoadFS adds a catalog for every JSON file of fsys matching pattern, such as an
+// [embed.FS]. The file name without extension is the locale, e.g. locales/bn-BD.json.
+func (t *Translator) LoadFS(fsys fs.FS, pattern string) error {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		base := path.Base(name)
+		c, err := ParseCatalog(strings.TrimSuffix(base, path.Ext(base)), data)
+		if err != nil {
+			return err
+		}
+		t.Add(c)
+	}
+	return nil
+}
+
+// Locale returns a localizer rendering errors in locale, e.g. bn-BD or bn_BD.
+func (t *Translator) Locale(locale string) *Localizer {
+	var chain []string
+	for _, l := range append(localeChain(locale), localeChain(t.fallback)...) {
+		if !slices.Contains(chain, l) {
+			chain = append(chain, l)
+		}
+	}
+	return &Localizer{translator: t, chain: chain}
+}
+
+// message returns the template of err in the first locale of chain with a message for its code.
+func (t *Translator) message(chain []string, err *ActionError) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, locale := range chain {
+		c, ok := t.catalogs[locale]
+		if !ok {
+			continue
+		}
+		m, ok := c.messages[err.Code]
+		if !ok {
+			continue
+		}
+		if form, ok := m.Forms[pluralCategory(locale, m.count(err.Params))]; ok {
+			return form, true
+		}
+		return m.Forms[PluralOther], true
+	}
+	return "", false
+}
+
+// count returns the number the plural form is chosen by.
+func (m Message) count(params map[string]any) float64 {
+	if m.Count != "" {
+		n, _ := paramNumber(params[m.Count])
+		return n
+	}
+	var count float64
+	numeric := 0
+	for _, p := range params {
+		if n, ok := paramNumber(p); ok {
+			count = n
+			numeric++
+		}
+	}
+	if numeric != 1 {
+		return 0
+	}
+	return count
+}
+
+func paramNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// normalizeLocale returns locale in lower case with - separators.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// localeChain returns locale and its parents, e.g. zh-hant-tw, zh-hant, zh.
+func localeChain(locale string) []string {
+	var chain []string
+	for l := normalizeLocale(locale); l != ""; {
+		chain = append(chain, l)
+		i := strings.LastIndexByte(l, '-')
+		if i < 0 {
+			break
+		}
+		l = l[:i]
+	}
+	return chain
+}
+
+// Localizer renders errors in one locale. Build it with [Translator.Locale].
+type Localizer struct {
+	translator *Translator
+	chain      []string
+}
+
+// Validate validates s like [Validate] and localizes the error.
+func (l *Localizer) Validate(s Schema) error {
+	return l.Localize(Validate(s))
+}
+
+// ValidateAll validates s like [ValidateAll] and localizes the errors.
+func (l *Localizer) ValidateAll(s Schema) error {
+	return l.Localize(ValidateAll(s))
+}
+
+// Localize returns err with the messages of the built-in actions rendered in
+// the locale, for Error() and the JSON output alike. It takes any error
+// returned by validation or the Parse helpers; the errors keep their codes
+// and work with errors.Is and errors.As as before.
+//
+// Example:
+//
+//	err := l.Localize(v.ParseBytesFull(body, &user))
+func (l *Localizer) Localize(err error) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case ValidationErrors:
+		localized := make(ValidationErrors, len(e))
+		for i, pipeErr := range e {
+			localized[i] = l.localizePipeError(pipeErr)
+		}
+		return localized
+	case *PipeError:
+		return l.localizePipeError(e)
+	case *ParseError:
+		localized := *e
+		localized.ValidationError = l.Localize(e.ValidationError)
+		return &localized
+	}
+	return l.localize(err)
+}
+
+func (l *Localizer) localizePipeError(e *PipeError) *PipeError {
+	if e == nil {
+		return nil
+	}
+	return &PipeError{Key: e.Key, Path: e.Path, Err: l.localize(e.Err)}
+}
+
+// localize replaces the message of err, keeping err in the chain.
+func (l *Localizer) localize(err error) error {
+	actionErr := actionErrorOf(err)
+	if actionErr == nil || actionErr.Code == "" || hasCustomMsg(err) {
+		return err
+	}
+	template, ok := l.translator.message(l.chain, actionErr)
+	if !ok {
+		return err
+	}
+	return newMessageError(err, template, actionErr.Value, actionErr.Params, false)
+}
+
+// hasCustomMsg reports whether the message of err was set with [ErrMsg],
+// either on the failed action or with [WithMsg].
+func hasCustomMsg(err error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case *messageError:
+			if e.custom {
+				return true
+			}
+		case *ActionError:
+			return e.custom
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
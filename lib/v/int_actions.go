@@ -1,24 +1,5 @@
 package v
 
-import (
-	"fmt"
-)
-
-// numberAction implements IntPipeAction for int validation.
-type numberAction struct {
-	errorMsg func(v int) string
-	validate func(v int) bool
-}
-
-// Run executes the validation function on the given int value.
-// Returns an error if validation fails.
-func (action *numberAction) Run(value int) error {
-	if !action.validate(value) {
-		return fmt.Errorf("%s", action.errorMsg(value))
-	}
-	return nil
-}
-
 // CustomNumber creates a custom validator using the provided validation function.
 // The optional ActionOptions parameter can be used to customize the error message.
 //
@@ -26,9 +7,13 @@ func (action *numberAction) Run(value int) error {
 //
 //	CustomNumber(func(v int) bool { return v%2 == 0 }, ErrMsg{msg: "must be even"})
 func CustomNumber(fn func(value int) bool, option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("invalid number", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "int.custom",
+				msg:  "invalid number",
+			}
 		},
 		validate: fn,
 	}
@@ -41,9 +26,15 @@ func CustomNumber(fn func(value int) bool, option ...ActionOptionFace) IntPipeAc
 //
 //	Gt(5) // validates v > 5
 func Gt(value int, option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be greater than specified value", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.gt",
+				msg:    "value must be greater than specified value",
+				params: map[string]any{"value": value},
+				schema: JSONSchema{"exclusiveMinimum": value},
+			}
 		},
 		validate: func(v int) bool {
 			return v > value
@@ -58,9 +49,15 @@ func Gt(value int, option ...ActionOptionFace) IntPipeAction {
 //
 //	Gte(5) // validates v >= 5
 func Gte(value int, option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be greater than or equal to specified value", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.gte",
+				msg:    "value must be greater than or equal to specified value",
+				params: map[string]any{"value": value},
+				schema: JSONSchema{"minimum": value},
+			}
 		},
 		validate: func(v int) bool {
 			return v >= value
@@ -69,12 +66,18 @@ func Gte(value int, option ...ActionOptionFace) IntPipeAction {
 }
 
 // IsIntString validates that a value can be represented as a valid integer.
-// This is a placeholder validator that always returns true.
-// The optional ActionOptions parameter can be used to customize the error message.
+// Every int can, so it always passes.
+//
+// Deprecated: validate the string input with [CoerceInt], which reports
+// strings that are not integers.
 func IsIntString(option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be a valid integer", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "int.int_string",
+				msg:  "value must be a valid integer",
+			}
 		},
 		validate: func(v int) bool {
 			return true
@@ -85,9 +88,14 @@ func IsIntString(option ...ActionOptionFace) IntPipeAction {
 // IsNegative validates that an int value is strictly less than zero.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsNegative(option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be negative", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.negative",
+				msg:    "value must be negative",
+				schema: JSONSchema{"exclusiveMaximum": 0},
+			}
 		},
 		validate: func(v int) bool {
 			return v < 0
@@ -98,9 +106,14 @@ func IsNegative(option ...ActionOptionFace) IntPipeAction {
 // IsPositive validates that an int value is strictly greater than zero.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsPositive(option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be positive", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.positive",
+				msg:    "value must be positive",
+				schema: JSONSchema{"exclusiveMinimum": 0},
+			}
 		},
 		validate: func(v int) bool {
 			return v > 0
@@ -111,9 +124,15 @@ func IsPositive(option ...ActionOptionFace) IntPipeAction {
 // NonZero validates that an int value is not equal to zero.
 // The optional ActionOptions parameter can be used to customize the error message.
 func NonZero(option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be non-zero", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:     "int.non_zero",
+				msg:      "value must be non-zero",
+				schema:   JSONSchema{"not": JSONSchema{"const": 0}},
+				required: true,
+			}
 		},
 		validate: func(v int) bool {
 			return v != 0
@@ -128,9 +147,15 @@ func NonZero(option ...ActionOptionFace) IntPipeAction {
 //
 //	Lt(10) // validates v < 10
 func Lt(value int, option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be less than specified value", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.lt",
+				msg:    "value must be less than specified value",
+				params: map[string]any{"value": value},
+				schema: JSONSchema{"exclusiveMaximum": value},
+			}
 		},
 		validate: func(v int) bool {
 			return v < value
@@ -145,9 +170,15 @@ func Lt(value int, option ...ActionOptionFace) IntPipeAction {
 //
 //	Lte(10) // validates v <= 10
 func Lte(value int, option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be less than or equal to specified value", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.lte",
+				msg:    "value must be less than or equal to specified value",
+				params: map[string]any{"value": value},
+				schema: JSONSchema{"maximum": value},
+			}
 		},
 		validate: func(v int) bool {
 			return v <= value
@@ -162,9 +193,15 @@ func Lte(value int, option ...ActionOptionFace) IntPipeAction {
 //
 //	Max(100) // validates v <= 100
 func Max(max int, option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value exceeds maximum", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.max",
+				msg:    "value exceeds maximum",
+				params: map[string]any{"max": max},
+				schema: JSONSchema{"maximum": max},
+			}
 		},
 		validate: func(v int) bool {
 			return v <= max
@@ -180,9 +217,15 @@ func Max(max int, option ...ActionOptionFace) IntPipeAction {
 //	Min(0) // validates v >= 0
 //	Min(10, ErrMsg{msg: "must be at least 10"})
 func Min(min int, option ...ActionOptionFace) IntPipeAction {
-	return &numberAction{
-		errorMsg: func(v int) string {
-			return extractMsg("value must be at least specified minimum", v, option...)
+	return &action[int]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.min",
+				msg:    "value must be at least specified minimum",
+				params: map[string]any{"min": min},
+				schema: JSONSchema{"minimum": min},
+			}
 		},
 		validate: func(v int) bool {
 			return v >= min
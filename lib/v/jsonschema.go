@@ -0,0 +1,456 @@
+package v
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// JSONSchema is a JSON Schema (draft 2020-12) document or subschema.
+// It marshals with encoding/json.
+type JSONSchema map[string]any
+
+// JSONSchemaDialect is the $schema of the documents returned by [JSONSchemaOf].
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// CustomKeyword is the extension keyword listing the actions that have no JSON Schema
+// equivalent, such as [CustomString] or an [ActionFunc]. Each entry is the default error
+// message of the action, or its Go type when it has none:
+//
+//	{"type": "string", "x-valgo-custom": ["must be a supported currency"]}
+const CustomKeyword = "x-valgo-custom"
+
+// JSONSchemaOf exports the rules of s as a JSON Schema document.
+// Rules is called on s as it is, so pass a zero value such as &User{}.
+//
+// Example:
+//
+//	doc, err := v.JSONSchemaOf(&User{})
+//	data, _ := json.MarshalIndent(doc, "", "  ")
+func JSONSchemaOf(s Schema) (JSONSchema, error) {
+	schema, err := schemaOf(s)
+	if err != nil {
+		return nil, err
+	}
+	schema["$schema"] = JSONSchemaDialect
+	return schema, nil
+}
+
+// JSONSchemaOfSet exports a [PipeSet] built with [NewPipesBuilder], [NewPipesMap]
+// or [PipeMap] as an object schema. Pipes are properties under their keys;
+// pipes that reject a missing value, such as [Required] or a pipe with
+// [NotEmpty], are listed as required. The cases of a [Switch] are exported
+// as if/then subschemas under allOf. The pipes of [When] and [Unless] and
+// cross-field rules are not exported, as their conditions are Go functions.
+func JSONSchemaOfSet(set PipeSet) (JSONSchema, error) {
+	pipes, err := pipesOf(set)
+	if err != nil {
+		return nil, err
+	}
+
+	object := newObjectSchema()
+	if err := object.add(pipes); err != nil {
+		return nil, err
+	}
+	schema := object.schema()
+	schema["type"] = "object"
+	return schema, nil
+}
+
+// ActionJSONSchema returns the JSON Schema keywords of action.
+// Actions without an equivalent are listed under [CustomKeyword].
+func ActionJSONSchema[T any](action Action[T]) JSONSchema {
+	schema, _ := actionSchema(action)
+	return schema
+}
+
+// WithSchema declares the JSON Schema keywords of an action, typically a custom one,
+// so it is exported with them instead of under [CustomKeyword].
+//
+// Example:
+//
+//	v.WithSchema(ValidCurrency(), v.JSONSchema{"enum": []string{"EUR", "USD"}})
+func WithSchema[T any](action Action[T], schema JSONSchema) Action[T] {
+	return &schemaAction[T]{Action: action, schema: schema}
+}
+
+// schemaAction is an action with declared JSON Schema keywords.
+type schemaAction[T any] struct {
+	Action[T]
+	schema JSONSchema
+}
+
+func (a *schemaAction[T]) jsonSchema() (JSONSchema, bool) {
+	return maps.Clone(a.schema), false
+}
+
+// describedAction is implemented by actions that know their JSON Schema keywords.
+// required reports whether the action rejects the zero value.
+type describedAction interface {
+	jsonSchema() (schema JSONSchema, required bool)
+}
+
+// describedPipe is implemented by pipes that know their JSON Schema.
+// required reports whether the field must be sent.
+type describedPipe interface {
+	jsonSchema() (schema JSONSchema, required bool, err error)
+}
+
+func schemaOf(s Schema) (JSONSchema, error) {
+	rules, err := rulesOf(s)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		return JSONSchema{"type": "object"}, nil
+	}
+	return JSONSchemaOfSet(rules)
+}
+
+// pipesOf returns the pipes of the pipe sets of this package.
+func pipesOf(set PipeSet) ([]PipeFace, error) {
+	switch s := set.(type) {
+	case *PipeRegistry:
+		return s.pipes, nil
+	case PipeMap:
+		return s.pipes(), nil
+	}
+	return nil, fmt.Errorf("v: cannot export %T as JSON Schema", set)
+}
+
+// objectSchema collects the properties of pipes into an object schema.
+type objectSchema struct {
+	properties JSONSchema
+	required   []string
+	// allOf holds the if/then subschemas of conditional pipes.
+	allOf []JSONSchema
+}
+
+func newObjectSchema() *objectSchema {
+	return &objectSchema{properties: JSONSchema{}}
+}
+
+func (o *objectSchema) schema() JSONSchema {
+	schema := JSONSchema{"properties": o.properties}
+	if len(o.required) > 0 {
+		schema["required"] = o.required
+	}
+	if len(o.allOf) > 0 {
+		schema["allOf"] = o.allOf
+	}
+	return schema
+}
+
+// add adds the schemas of pipes to the object.
+func (o *objectSchema) add(pipes []PipeFace) error {
+	for _, pipe := range pipes {
+		if conditional, ok := pipe.(*conditionalPipe); ok {
+			if err := o.addConditional(conditional); err != nil {
+				return err
+			}
+			continue
+		}
+
+		described, ok := pipe.(describedPipe)
+		if !ok {
+			continue
+		}
+		schema, isRequired, err := described.jsonSchema()
+		if err != nil {
+			return err
+		}
+
+		key := pipe.Key()
+		if key == "" {
+			// an unkeyed nested set, such as the Rules of a struct with tag rules,
+			// contributes its properties to the parent
+			if nested, ok := schema["properties"].(JSONSchema); ok {
+				maps.Copy(o.properties, nested)
+				if keys, ok := schema["required"].([]string); ok {
+					o.required = append(o.required, keys...)
+				}
+				if allOf, ok := schema["allOf"].([]JSONSchema); ok {
+					o.allOf = append(o.allOf, allOf...)
+				}
+			}
+			continue
+		}
+
+		if existing, ok := o.properties[key].(JSONSchema); ok {
+			mergeSchema(existing, schema)
+		} else {
+			o.properties[key] = schema
+		}
+		if isRequired && !slices.Contains(o.required, key) {
+			o.required = append(o.required, key)
+		}
+	}
+	return nil
+}
+
+// addConditional adds the cases of a [Switch] as if/then subschemas: a case
+// applies when its field holds the case value, the otherwise pipes when the
+// field holds none of them or is missing. [When] and [Unless] are left out.
+func (o *objectSchema) addConditional(pipe *conditionalPipe) error {
+	sw := pipe.switchCases
+	if sw == nil {
+		return nil
+	}
+	if len(sw.caseKeys) == 0 {
+		return o.add(sw.otherwise)
+	}
+
+	for _, caseKey := range sw.caseKeys {
+		cond := JSONSchema{
+			"properties": JSONSchema{sw.key: JSONSchema{"const": caseKey}},
+			"required":   []string{sw.key},
+		}
+		if err := o.addBranch(cond, sw.cases[caseKey]); err != nil {
+			return err
+		}
+	}
+	cond := JSONSchema{"properties": JSONSchema{sw.key: JSONSchema{"not": JSONSchema{"enum": sw.caseKeys}}}}
+	return o.addBranch(cond, sw.otherwise)
+}
+
+// addBranch adds an if/then subschema applying the schemas of pipes when cond holds.
+func (o *objectSchema) addBranch(cond JSONSchema, pipes []PipeFace) error {
+	branch := newObjectSchema()
+	if err := branch.add(pipes); err != nil {
+		return err
+	}
+	if len(branch.properties) == 0 && len(branch.allOf) == 0 {
+		return nil
+	}
+	o.allOf = append(o.allOf, JSONSchema{"if": cond, "then": branch.schema()})
+	return nil
+}
+
+// actionSchema returns the JSON Schema keywords of action.
+func actionSchema[T any](a Action[T]) (JSONSchema, bool) {
+	switch act := a.(type) {
+	case transformer[T]:
+		return JSONSchema{}, false
+	case *action[T]:
+		info := act.describe()
+		if info.schema != nil {
+			return info.schema, info.required
+		}
+		var zero T
+		msg, _ := extractMsg(info.msg, zero, act.options...)
+		return customSchema(msg), info.required
+	case describedAction:
+		return act.jsonSchema()
+	}
+	return customSchema(fmt.Sprintf("%T", a)), false
+}
+
+func customSchema(description string) JSONSchema {
+	return JSONSchema{CustomKeyword: []string{description}}
+}
+
+// valueSchema returns the schema of a value of type T validated by actions.
+func valueSchema[T any](actions []Action[T]) (JSONSchema, bool) {
+	schema := typeSchema(reflect.TypeFor[T]())
+	required := false
+	for _, action := range actions {
+		keywords, isRequired := actionSchema(action)
+		mergeSchema(schema, keywords)
+		required = required || isRequired
+	}
+	return schema, required
+}
+
+var timeReflectType = reflect.TypeFor[time.Time]()
+
+// typeSchema returns the schema of the JSON encoding of type t.
+func typeSchema(t reflect.Type) JSONSchema {
+	if t == timeReflectType {
+		return JSONSchema{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return JSONSchema{"type": "string"}
+	case reflect.Bool:
+		return JSONSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return JSONSchema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return JSONSchema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return JSONSchema{"type": "string", "contentEncoding": "base64"}
+		}
+		return JSONSchema{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return JSONSchema{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return JSONSchema{"type": "object"}
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	}
+	return JSONSchema{}
+}
+
+// mergeSchema adds the keywords of src to dst. Nested schemas are merged,
+// keywords with another value are kept side by side under allOf.
+func mergeSchema(dst, src JSONSchema) {
+	for keyword, value := range src {
+		existing, ok := dst[keyword]
+		switch {
+		case !ok:
+			dst[keyword] = value
+		case keyword == CustomKeyword:
+			dst[keyword] = append(existing.([]string), value.([]string)...)
+		case reflect.DeepEqual(existing, value):
+		default:
+			nestedDst, dstIsSchema := existing.(JSONSchema)
+			nestedSrc, srcIsSchema := value.(JSONSchema)
+			if dstIsSchema && srcIsSchema && keyword != "not" && keyword != "contains" {
+				mergeSchema(nestedDst, nestedSrc)
+				continue
+			}
+			allOf, _ := dst["allOf"].([]JSONSchema)
+			dst["allOf"] = append(allOf, JSONSchema{keyword: value})
+		}
+	}
+}
+
+// nullable lets schema accept null as well.
+func nullable(schema JSONSchema) JSONSchema {
+	if t, ok := schema["type"].(string); ok {
+		schema["type"] = []string{t, "null"}
+	}
+	return schema
+}
+
+// pipeSchema returns the schema of a pipe that may not describe itself.
+func pipeSchema(pipe PipeFace) (JSONSchema, bool, error) {
+	if described, ok := pipe.(describedPipe); ok {
+		return described.jsonSchema()
+	}
+	return JSONSchema{}, false, nil
+}
+
+// safeElementSchema returns the schema of the pipe built for a zero element,
+// or nil when building it panics.
+func safeElementSchema(build func() PipeFace) (schema JSONSchema) {
+	defer func() {
+		if recover() != nil {
+			schema = nil
+		}
+	}()
+	schema, _, err := pipeSchema(build())
+	if err != nil {
+		return nil
+	}
+	return schema
+}
+
+func (pipe *Pipe[T]) jsonSchema() (JSONSchema, bool, error) {
+	schema, required := valueSchema(pipe.actions)
+	return schema, required, nil
+}
+
+func (pipe *pointerPipe[T]) jsonSchema() (JSONSchema, bool, error) {
+	schema, _ := valueSchema(pipe.actions)
+	switch pipe.mode {
+	case nilRequired:
+		return schema, true, nil
+	case nilNullable:
+		return nullable(schema), true, nil
+	}
+	return nullable(schema), false, nil
+}
+
+func (pipe *objectPipe) jsonSchema() (JSONSchema, bool, error) {
+	if pipe.schema != nil && isNilSchema(pipe.schema) {
+		// describe the schema of a nil pointer through a new value of its type
+		t := reflect.TypeOf(pipe.schema)
+		if t == nil || t.Kind() != reflect.Pointer {
+			return JSONSchema{"type": "object"}, false, nil
+		}
+		s, ok := reflect.New(t.Elem()).Interface().(Schema)
+		if !ok {
+			return JSONSchema{"type": "object"}, false, nil
+		}
+		schema, err := schemaOf(s)
+		return schema, false, err
+	}
+
+	set, err := pipe.rules()
+	if err != nil {
+		return nil, false, err
+	}
+	if set == nil {
+		return JSONSchema{"type": "object"}, false, nil
+	}
+	schema, err := JSONSchemaOfSet(set)
+	return schema, false, err
+}
+
+func (p *presencePipe) jsonSchema() (JSONSchema, bool, error) {
+	schema, _, err := pipeSchema(p.pipe)
+	return schema, p.required, err
+}
+
+func (p *alwaysPipe) jsonSchema() (JSONSchema, bool, error) {
+	return pipeSchema(p.pipe)
+}
+
+func (pipe *CoercePipe[T]) jsonSchema() (JSONSchema, bool, error) {
+	return JSONSchema{"type": "string"}, false, nil
+}
+
+func (each *eachAction[T]) jsonSchema() (JSONSchema, bool) {
+	if each.pipe != nil {
+		var zero T
+		if items := safeElementSchema(func() PipeFace { return each.pipe(zero) }); items != nil {
+			return JSONSchema{"items": items}, false
+		}
+		return JSONSchema{}, false
+	}
+	items, _ := valueSchema(each.actions)
+	return JSONSchema{"items": items}, false
+}
+
+func (entries *mapEntriesAction[K, V]) jsonSchema() (JSONSchema, bool) {
+	var k K
+	var v V
+	schema := safeElementSchema(func() PipeFace { return entries.pipe(k, v) })
+	if schema == nil {
+		return JSONSchema{}, false
+	}
+	return JSONSchema{entries.keyword: schema}, false
+}
+
+func (ks *keySetAction[K, V]) jsonSchema() (JSONSchema, bool) {
+	keys := make([]string, len(ks.keys))
+	for i, k := range ks.keys {
+		keys[i] = fmt.Sprint(k)
+	}
+	if !ks.present {
+		return JSONSchema{"required": keys}, false
+	}
+	forbidden := JSONSchema{}
+	for _, k := range keys {
+		forbidden[k] = false
+	}
+	return JSONSchema{"properties": forbidden}, false
+}
+
+func (c *combinator[T]) jsonSchema() (JSONSchema, bool) {
+	schemas := make([]JSONSchema, len(c.actions))
+	for i, action := range c.actions {
+		schemas[i] = ActionJSONSchema(action)
+	}
+	return JSONSchema{c.keyword: schemas}, false
+}
+
+func (m *messageAction[T]) jsonSchema() (JSONSchema, bool) {
+	return actionSchema(m.action)
+}
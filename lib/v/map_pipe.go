@@ -0,0 +1,193 @@
+package v
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// MapAction defines the interface for actions that validate a whole map.
+type MapAction[K comparable, V any] = Action[map[K]V]
+
+// MapPipe creates a new validation pipe for a map of dynamic keys, such as
+// metadata map[string]string. [Keys] and [Values] run an action chain on every
+// key and value, [MinKeys], [MaxKeys], [RequiredKeys] and [ForbiddenKeys] validate
+// the map itself. Entry errors are keyed like metadata["region"].
+//
+// Entries are visited in the order of their formatted keys, so errors are reported
+// in the same order on every run.
+//
+// Example:
+//
+//	v.Entry("metadata").Pipe(v.MapPipe(r.Metadata,
+//	    v.MaxKeys[string, string](20),
+//	    v.Keys[string, string](v.IsAlphaNumeric()),
+//	    v.Values[string](v.MaxLength(256)),
+//	))
+func MapPipe[K comparable, V any](m map[K]V, actions ...MapAction[K, V]) *Pipe[map[K]V] {
+	return NewPipe(m, actions...)
+}
+
+// sortedMapKeys returns the keys of m ordered by their formatted value.
+func sortedMapKeys[K comparable, V any](m map[K]V) []K {
+	return slices.SortedFunc(maps.Keys(m), func(a, b K) int {
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+}
+
+// mapEntryPath returns the path segment of the entry with key k.
+func mapEntryPath[K comparable](k K) PathSegment {
+	return PathMapKey(fmt.Sprint(k))
+}
+
+// mapEntriesAction runs a pipe built from every entry of a map.
+type mapEntriesAction[K comparable, V any] struct {
+	pipe func(k K, v V) PipeFace
+	// keyword is the JSON Schema keyword of the entry schema.
+	keyword string
+}
+
+// Keys validates every key of a map with the given actions.
+func Keys[K comparable, V any](actions ...Action[K]) MapAction[K, V] {
+	return &mapEntriesAction[K, V]{
+		keyword: "propertyNames",
+		pipe: func(k K, _ V) PipeFace {
+			return NewPipe(k, actions...)
+		},
+	}
+}
+
+// Values validates every value of a map with the given actions.
+func Values[K comparable, V any](actions ...Action[V]) MapAction[K, V] {
+	return &mapEntriesAction[K, V]{
+		keyword: "additionalProperties",
+		pipe: func(_ K, v V) PipeFace {
+			return NewPipe(v, actions...)
+		},
+	}
+}
+
+// ValuesPipe validates every value of a map with the pipe returned by build.
+// Use it to validate values with a nested schema.
+func ValuesPipe[K comparable, V any](build func(value V) PipeFace) MapAction[K, V] {
+	return &mapEntriesAction[K, V]{
+		keyword: "additionalProperties",
+		pipe: func(_ K, v V) PipeFace {
+			return build(v)
+		},
+	}
+}
+
+// Run validates the entries and stops at the first failing one.
+func (entries *mapEntriesAction[K, V]) Run(m map[K]V) error {
+	return entries.runWith(m, runContext{})
+}
+
+func (entries *mapEntriesAction[K, V]) runWith(m map[K]V, ctx runContext) error {
+	var validationErrors ValidationErrors
+
+	for _, k := range sortedMapKeys(m) {
+		err := runPipe(entries.pipe(k, m[k]), ctx.at(mapEntryPath(k)))
+		if err == nil {
+			continue
+		}
+
+		if err := nestErrors(err, ctx, mapEntryPath(k)); err != nil {
+			if !ctx.all {
+				return err
+			}
+			validationErrors = append(validationErrors, err.(ValidationErrors)...)
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+// MinKeys validates that a map has at least min entries.
+// The optional ActionOptions parameter can be used to customize the error message.
+func MinKeys[K comparable, V any](min int, option ...ActionOptionFace) MapAction[K, V] {
+	return &action[map[K]V]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "map.min_keys",
+				msg:    fmt.Sprintf("must contain at least %d keys", min),
+				params: map[string]any{"min": min},
+				schema: JSONSchema{"minProperties": min},
+			}
+		},
+		validate: func(v map[K]V) bool {
+			return len(v) >= min
+		},
+	}
+}
+
+// MaxKeys validates that a map has at most max entries.
+// The optional ActionOptions parameter can be used to customize the error message.
+func MaxKeys[K comparable, V any](max int, option ...ActionOptionFace) MapAction[K, V] {
+	return &action[map[K]V]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "map.max_keys",
+				msg:    fmt.Sprintf("must contain at most %d keys", max),
+				params: map[string]any{"max": max},
+				schema: JSONSchema{"maxProperties": max},
+			}
+		},
+		validate: func(v map[K]V) bool {
+			return len(v) <= max
+		},
+	}
+}
+
+// keySetAction reports every key of a map that fails a membership check.
+type keySetAction[K comparable, V any] struct {
+	keys    []K
+	present bool
+	code    string
+	msg     string
+}
+
+// RequiredKeys validates that a map contains every one of keys.
+// Every missing key is reported under its own entry, e.g. metadata["region"].
+func RequiredKeys[K comparable, V any](keys ...K) MapAction[K, V] {
+	return &keySetAction[K, V]{keys: keys, present: false, code: "map.required_key", msg: "key is required"}
+}
+
+// ForbiddenKeys validates that a map contains none of keys.
+// Every forbidden key found is reported under its own entry, e.g. metadata["internal"].
+func ForbiddenKeys[K comparable, V any](keys ...K) MapAction[K, V] {
+	return &keySetAction[K, V]{keys: keys, present: true, code: "map.forbidden_key", msg: "key is not allowed"}
+}
+
+// Run reports the first failing key.
+func (ks *keySetAction[K, V]) Run(m map[K]V) error {
+	return ks.runWith(m, runContext{})
+}
+
+func (ks *keySetAction[K, V]) runWith(m map[K]V, ctx runContext) error {
+	var validationErrors ValidationErrors
+
+	for _, k := range ks.keys {
+		if _, ok := m[k]; ok != ks.present {
+			continue
+		}
+
+		err := &PipeError{Err: &ActionError{Code: ks.code, Msg: ks.msg, Params: map[string]any{"key": k}}}
+		err.setPath(Path{mapEntryPath(k)})
+		if !ctx.all {
+			return err
+		}
+		validationErrors = append(validationErrors, err)
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+	return nil
+}
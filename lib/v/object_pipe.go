@@ -0,0 +1,101 @@
+package v
+
+import "reflect"
+
+// objectPipe validates a nested [PipeSet] and reports its errors under the pipe key.
+type objectPipe struct {
+	key   string
+	rules func() (PipeSet, error)
+	// schema is the nested schema of a [SchemaPipe], nil for an [ObjectPipe].
+	schema Schema
+}
+
+// ObjectPipe creates a pipe that validates a nested [PipeSet] as a single field.
+// Errors of the nested set are reported under the pipe key, e.g. address.city,
+// with the full location in [PipeError.Path].
+//
+// Example:
+//
+//	v.NewPipesMap(v.PipeMap{
+//	    "name": v.StringPipe(u.Name, v.NotEmpty()),
+//	    "address": v.ObjectPipe(v.PipeMap{
+//	        "city": v.StringPipe(u.Address.City, v.NotEmpty()),
+//	    }),
+//	})
+func ObjectPipe(set PipeSet) PipeFace {
+	return &objectPipe{
+		rules: func() (PipeSet, error) {
+			return set, nil
+		},
+	}
+}
+
+// SchemaPipe creates a pipe that validates a nested [Schema] with its own [Schema.Rules].
+// A nil schema, including a nil pointer, is skipped.
+//
+// Example:
+//
+//	func (u *User) Rules() (v.PipeSet, error) {
+//	    return v.PipeMap{
+//	        "name":    v.StringPipe(u.Name, v.NotEmpty()),
+//	        "address": v.SchemaPipe(&u.Address),
+//	    }, nil
+//	}
+func SchemaPipe(s Schema) PipeFace {
+	return &objectPipe{
+		schema: s,
+		rules: func() (PipeSet, error) {
+			if isNilSchema(s) {
+				return nil, nil
+			}
+			return rulesOf(s)
+		},
+	}
+}
+
+// isNilSchema reports whether s is nil or a nil pointer.
+func isNilSchema(s Schema) bool {
+	if s == nil {
+		return true
+	}
+	rv := reflect.ValueOf(s)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// setKey sets the validation key for this pipe.
+// This key is used in error messages to identify which field failed validation.
+func (pipe *objectPipe) setKey(k string) {
+	pipe.key = k
+}
+
+// Key returns the validation key associated with this pipe.
+func (pipe *objectPipe) Key() string {
+	return pipe.key
+}
+
+// Validate validates the nested set and returns its first error.
+func (pipe *objectPipe) Validate() error {
+	return pipe.validateWith(runContext{})
+}
+
+// ValidateAll validates the nested set and returns all of its errors.
+func (pipe *objectPipe) ValidateAll() error {
+	return pipe.validateWith(runContext{all: true})
+}
+
+func (pipe *objectPipe) validateWith(ctx runContext) error {
+	set, err := pipe.rules()
+	if err != nil {
+		return NewPipeError(pipe.key, err)
+	}
+	if set == nil {
+		return nil
+	}
+
+	err = runSet(set, ctx.at(keyPath(pipe.key)...))
+	if err == nil {
+		return nil
+	}
+
+	return nestErrors(err, ctx, keyPath(pipe.key)...)
+}
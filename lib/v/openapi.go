@@ -0,0 +1,165 @@
+package v
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// OpenAPIVersion is the version of the documents written by [OpenAPI].
+const OpenAPIVersion = "3.1.0"
+
+// Names of the validation error components added to every [OpenAPI] document.
+const (
+	PipeErrorComponent  = "PipeError"
+	ParseErrorComponent = "ParseError"
+)
+
+// OpenAPI builds an OpenAPI 3.1 document with a components.schemas entry for
+// every added schema, generated with [JSONSchemaOf]. It also declares the
+// [PipeErrorComponent] and [ParseErrorComponent] schemas, matching the JSON of
+// [PipeError] and [ParseError], and a ValidationError response using them.
+//
+// Example:
+//
+//	doc := v.NewOpenAPI("Accounts API", "1.0.0").
+//	    Add("User", &User{}).
+//	    Add("Order", &Order{})
+//
+//	data, err := doc.YAML()
+type OpenAPI struct {
+	title   string
+	version string
+	names   []string
+	schemas map[string]Schema
+}
+
+// NewOpenAPI creates a document with the given info title and version.
+func NewOpenAPI(title, version string) *OpenAPI {
+	return &OpenAPI{
+		title:   title,
+		version: version,
+		schemas: make(map[string]Schema),
+	}
+}
+
+// Add adds the schema s under name. Rules is called on s as it is,
+// so pass a zero value such as &User{}.
+func (o *OpenAPI) Add(name string, s Schema) *OpenAPI {
+	if _, ok := o.schemas[name]; !ok {
+		o.names = append(o.names, name)
+	}
+	o.schemas[name] = s
+	return o
+}
+
+// Document returns the OpenAPI document as a JSON value.
+func (o *OpenAPI) Document() (map[string]any, error) {
+	schemas := map[string]any{
+		PipeErrorComponent:  pipeErrorSchema(),
+		ParseErrorComponent: parseErrorSchema(),
+	}
+	for _, name := range o.names {
+		if _, ok := schemas[name]; ok {
+			return nil, fmt.Errorf("v: OpenAPI schema %s is reserved for validation errors", name)
+		}
+		schema, err := schemaOf(o.schemas[name])
+		if err != nil {
+			return nil, fmt.Errorf("v: OpenAPI schema %s: %w", name, err)
+		}
+		schemas[name] = schema
+	}
+
+	return map[string]any{
+		"openapi": OpenAPIVersion,
+		"info": map[string]any{
+			"title":   o.title,
+			"version": o.version,
+		},
+		"components": map[string]any{
+			"schemas": schemas,
+			"responses": map[string]any{
+				"ValidationError": map[string]any{
+					"description": "The request failed to parse or validate.",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": componentRef(ParseErrorComponent),
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// JSON returns the indented JSON encoding of the document.
+func (o *OpenAPI) JSON() ([]byte, error) {
+	doc, err := o.Document()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// YAML returns the YAML encoding of the document. Keys are sorted, like in [OpenAPI.JSON].
+func (o *OpenAPI) YAML() ([]byte, error) {
+	data, err := o.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	writeYAML(&b, doc, 0)
+	return b.Bytes(), nil
+}
+
+func componentRef(name string) JSONSchema {
+	return JSONSchema{"$ref": "#/components/schemas/" + name}
+}
+
+// pipeErrorSchema describes the JSON of a [PipeError].
+func pipeErrorSchema() JSONSchema {
+	return JSONSchema{
+		"type": "object",
+		"properties": JSONSchema{
+			"key": JSONSchema{"type": "string", "description": "Path of the invalid field, e.g. address.city."},
+			"msg": JSONSchema{"type": "string"},
+			"code": JSONSchema{
+				"type":        "string",
+				"description": "Stable code of the failed rule, e.g. string.min_length.",
+			},
+			"params": JSONSchema{
+				"type":        "object",
+				"description": "Arguments of the failed rule, e.g. {\"min\": 3}.",
+			},
+		},
+		"required": []string{"key", "msg"},
+	}
+}
+
+// parseErrorSchema describes the JSON of a [ParseError]. Its validation_error
+// is a single [PipeError] or, when every error was collected, a list of them.
+func parseErrorSchema() JSONSchema {
+	message := JSONSchema{"type": "string"}
+	return JSONSchema{
+		"type": "object",
+		"properties": JSONSchema{
+			"pre_error":   message,
+			"parse_error": message,
+			"validation_error": JSONSchema{
+				"oneOf": []JSONSchema{
+					componentRef(PipeErrorComponent),
+					{"type": "array", "items": componentRef(PipeErrorComponent)},
+				},
+			},
+			"post_error": message,
+		},
+	}
+}
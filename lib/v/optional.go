@@ -0,0 +1,95 @@
+package v
+
+import "errors"
+
+// ErrRequired is the error of a [Required] pipe whose value is missing.
+// Use errors.Is(err, v.ErrRequired) to detect it.
+var ErrRequired = errors.New("is required")
+
+// requiredError returns the failure of a missing value: an [ActionError] with
+// the code required that wraps [ErrRequired].
+func requiredError() *ActionError {
+	return &ActionError{Code: "required", Msg: ErrRequired.Error(), cause: ErrRequired}
+}
+
+// nilMode tells a pointer pipe how to treat a nil value.
+type nilMode uint8
+
+const (
+	nilOptional nilMode = iota
+	nilNullable
+	nilRequired
+)
+
+// pointerPipe validates the value behind a pointer, e.g. a *string field
+// decoded from JSON, and handles nil according to its mode.
+type pointerPipe[T any] struct {
+	key     string
+	value   *T
+	actions []Action[T]
+	plain   bool
+	mode    nilMode
+}
+
+// Optional creates a pipe for a value that may be absent.
+// A nil value skips the action chain, any other value runs it.
+//
+// Example:
+//
+//	"nickname": v.Optional(u.Nickname, v.MinLength(3)), // u.Nickname is *string
+func Optional[T any](value *T, actions ...Action[T]) PipeFace {
+	return &pointerPipe[T]{value: value, actions: actions, plain: plainActions(actions), mode: nilOptional}
+}
+
+// Nullable creates a pipe for a value that may be null.
+// A nil value skips the action chain, any other value runs it.
+//
+// When the schema was filled by a Parse helper and therefore knows which fields
+// were sent, the field must be present: {"bio":null} passes while {} fails
+// with [ErrRequired].
+func Nullable[T any](value *T, actions ...Action[T]) PipeFace {
+	return &pointerPipe[T]{value: value, actions: actions, plain: plainActions(actions), mode: nilNullable}
+}
+
+// Required creates a pipe for a value that must be set.
+// A nil value fails with [ErrRequired], any other value runs the action chain.
+//
+// Example:
+//
+//	"age": v.Required(u.Age, v.Min(18)), // u.Age is *int
+func Required[T any](value *T, actions ...Action[T]) PipeFace {
+	return &pointerPipe[T]{value: value, actions: actions, plain: plainActions(actions), mode: nilRequired}
+}
+
+// setKey sets the validation key for this pipe.
+// This key is used in error messages to identify which field failed validation.
+func (pipe *pointerPipe[T]) setKey(k string) {
+	pipe.key = k
+}
+
+// Key returns the validation key associated with this pipe.
+func (pipe *pointerPipe[T]) Key() string {
+	return pipe.key
+}
+
+// Validate handles a nil value according to the pipe mode and otherwise
+// runs all validation actions in sequence.
+func (pipe *pointerPipe[T]) Validate() error {
+	return pipe.validateWith(runContext{})
+}
+
+func (pipe *pointerPipe[T]) validateWith(ctx runContext) error {
+	if pipe.mode == nilNullable && ctx.lookup(pipe.key) == presenceAbsent {
+		return NewPipeError(pipe.key, requiredError())
+	}
+
+	if pipe.value == nil {
+		if pipe.mode == nilRequired {
+			return NewPipeError(pipe.key, requiredError())
+		}
+		return nil
+	}
+
+	inner := Pipe[T]{key: pipe.key, value: *pipe.value, actions: pipe.actions, plain: pipe.plain}
+	return inner.validateWith(ctx)
+}
@@ -0,0 +1,104 @@
+package v
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPanic is wrapped by the error reported for a pipe whose action panicked
+// during parallel validation. Use errors.Is(err, v.ErrPanic) to detect it.
+var ErrPanic = errors.New("pipe panicked")
+
+// parallelConfig holds the settings for a parallel validation run.
+type parallelConfig struct {
+	concurrency int
+}
+
+// ParallelOption configures ValidateAllParallel.
+type ParallelOption func(*parallelConfig)
+
+// Concurrency limits the number of pipes validated at the same time.
+// A limit <= 0 falls back to the default of runtime.GOMAXPROCS(0).
+func Concurrency(limit int) ParallelOption {
+	return func(c *parallelConfig) {
+		c.concurrency = limit
+	}
+}
+
+func newParallelConfig(options []ParallelOption) parallelConfig {
+	cfg := parallelConfig{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	if cfg.concurrency <= 0 {
+		cfg.concurrency = runtime.GOMAXPROCS(0)
+	}
+	return cfg
+}
+
+// parallelValidator is implemented by pipe sets that can validate their pipes concurrently
+// with the settings of an enclosing run.
+type parallelValidator interface {
+	validateParallelWith(ctx runContext, cfg parallelConfig) error
+}
+
+// validatePipesParallel validates pipes with a bounded worker pool.
+// Errors are collected per pipe and reported in the same order as the sequential path.
+func validatePipesParallel(pipes []PipeFace, ctx runContext, cfg parallelConfig) error {
+	results := make([]error, len(pipes))
+	ctx = ctx.withFields(pipes)
+
+	workers := min(cfg.concurrency, len(pipes))
+	if workers <= 1 {
+		for i, pipe := range pipes {
+			if !ctx.skip(pipe) {
+				results[i] = safeRun(pipe, ctx)
+			}
+		}
+	} else {
+		var next atomic.Int64
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for range workers {
+			go func() {
+				defer wg.Done()
+				for {
+					i := int(next.Add(1) - 1)
+					if i >= len(pipes) {
+						return
+					}
+					if !ctx.skip(pipes[i]) {
+						results[i] = safeRun(pipes[i], ctx)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	var validationErrors ValidationErrors
+	for i, err := range results {
+		if err != nil {
+			validationErrors = append(validationErrors, collectErrors(pipes[i].Key(), err)...)
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+// safeRun validates pipe within ctx and turns a panic into a [PipeError]
+// instead of crashing the worker goroutine.
+func safeRun(pipe PipeFace, ctx runContext) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = NewPipeError(pipe.Key(), fmt.Errorf("%w: %v", ErrPanic, r))
+		}
+	}()
+	return runPipe(pipe, ctx)
+}
@@ -1,6 +1,7 @@
 package v
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 )
@@ -10,39 +11,91 @@ import (
 //
 // This expect to Implement [Schema.Rules] and return a [PipeSet]
 // if [Schema.Rules] return nil, it will skip the validation.
-type Include struct{}
+//
+// Include also records which JSON fields were sent when the struct is
+// filled by a Parse helper, see [Include.Present].
+type Include struct {
+	// presence is a pointer so structs embedding Include stay comparable.
+	presence *lazyPresence
+}
 
 func (s *Include) Rules() (PipeSet, error) {
 	return nil, nil
 }
 
+// SetPresence records the JSON paths sent in the payload.
+// The Parse helpers call it before [Schema.Rules].
+func (s *Include) SetPresence(p Presence) {
+	s.presence = knownPresence(p)
+}
+
+// Presence returns the JSON paths sent in the payload,
+// or nil if the struct wasn't filled by a Parse helper.
+func (s *Include) Presence() Presence {
+	return s.presence.get()
+}
+
+// Present reports whether the JSON path, e.g. "age" or "address.city",
+// was sent in the payload. Use it in [Schema.Rules] to tell {"age":0} from {}.
+func (s *Include) Present(path string) bool {
+	return s.Presence().Has(path)
+}
+
+func (s *Include) setPayloadPresence(presence *lazyPresence) {
+	s.presence = presence
+}
+
+func (s *Include) payloadPresence() *lazyPresence {
+	return s.presence
+}
+
+// preCheckKey is the key of the error reported when [Schema.Rules] fails.
+const preCheckKey = "_pre-check"
+
 func Validate(s Schema) error {
-	rules, err := s.Rules()
+	rules, err := rulesOf(s)
 	if err != nil {
-		return NewPipeError("_pre-check", err)
+		return NewPipeError(preCheckKey, err)
 	}
 	if rules == nil {
 		return nil
 	}
-	return rules.Validate()
+	return runSet(rules, runContext{presence: presenceOf(s)})
 }
 
 func ValidateAll(s Schema) error {
-	rules, err := s.Rules()
+	rules, err := rulesOf(s)
 
 	if err != nil {
-		return ValidationErrors{NewPipeError("_pre-check", err)}
+		return ValidationErrors{NewPipeError(preCheckKey, err)}
 	}
 
 	if rules == nil {
 		return nil
 	}
 
-	return rules.ValidateAll()
+	return runSet(rules, runContext{all: true, presence: presenceOf(s)})
 }
 
-func ValidateAllParallel(s Schema) error {
-	return ValidateAll(s)
+// ValidateAllParallel is like [ValidateAll] but validates the pipes concurrently
+// when the [PipeSet] returned by [Schema.Rules] supports it (both [PipeRegistry]
+// and [PipeMap] do). Other pipe sets fall back to ValidateAll.
+func ValidateAllParallel(s Schema, options ...ParallelOption) error {
+	rules, err := rulesOf(s)
+
+	if err != nil {
+		return ValidationErrors{NewPipeError(preCheckKey, err)}
+	}
+
+	if rules == nil {
+		return nil
+	}
+
+	ctx := runContext{all: true, presence: presenceOf(s)}
+	if parallel, ok := rules.(parallelValidator); ok {
+		return parallel.validateParallelWith(ctx, newParallelConfig(options))
+	}
+	return runSet(rules, ctx)
 }
 
 // Parse a schema from [io.Reader] and Validate.
@@ -53,9 +106,7 @@ func ValidateAllParallel(s Schema) error {
 // Returns nil if there is no error. but return [ParseError] if there is
 // any kind of error exists.
 func Parse(reader io.Reader, to Schema) error {
-	return parseWithDecoder(func(v any) error {
-		return json.NewDecoder(reader).Decode(v)
-	}, to, false)
+	return parseWithDecoder(readerDecoder(reader), to, parseMode{})
 }
 
 // ParseFull a schema from [io.Reader] and Validate.
@@ -64,9 +115,7 @@ func Parse(reader io.Reader, to Schema) error {
 // Returns nil if there is no error. but return [ParseError] if there is
 // any kind of error exists.
 func ParseFull(reader io.Reader, to Schema) error {
-	return parseWithDecoder(func(v any) error {
-		return json.NewDecoder(reader).Decode(v)
-	}, to, true)
+	return parseWithDecoder(readerDecoder(reader), to, parseMode{full: true})
 }
 
 // ParseBytes a schema from []bytes and Validate.
@@ -75,9 +124,7 @@ func ParseFull(reader io.Reader, to Schema) error {
 // ParseBytes doesn't return full list of errors, instead
 // when the first error happen it return immediately.
 func ParseBytes(data []byte, to Schema) error {
-	return parseWithDecoder(func(v any) error {
-		return json.Unmarshal(data, v)
-	}, to, false)
+	return parseWithDecoder(bytesDecoder(data), to, parseMode{})
 }
 
 // ParseBytesFull a schema from []bytes and Validate.
@@ -85,17 +132,51 @@ func ParseBytes(data []byte, to Schema) error {
 //
 // returns full list of errors.
 func ParseBytesFull(data []byte, to Schema) error {
-	return parseWithDecoder(func(v any) error {
-		return json.Unmarshal(data, v)
-	}, to, true)
+	return parseWithDecoder(bytesDecoder(data), to, parseMode{full: true})
 }
 
-func parseWithDecoder(decode func(any) error, to Schema, full bool) error {
-	if err := decode(to); err != nil {
-		return &ParseError{ParseError: err}
+// parseMode selects how parseWithDecoder validates the decoded schema.
+type parseMode struct {
+	// full returns all errors instead of the first one.
+	full bool
+	// partial only validates the fields present in the payload.
+	partial bool
+}
+
+// decoder decodes the payload of a Parse helper into v. With keep set it also
+// returns the payload, so the sent fields can be recorded from it later.
+type decoder func(v any, keep bool) ([]byte, error)
+
+// readerDecoder decodes the first JSON value of reader. The kept payload may
+// be followed by the bytes read past that value.
+func readerDecoder(reader io.Reader) decoder {
+	return func(v any, keep bool) ([]byte, error) {
+		if !keep {
+			return nil, json.NewDecoder(reader).Decode(v)
+		}
+		var read bytes.Buffer
+		err := json.NewDecoder(io.TeeReader(reader, &read)).Decode(v)
+		return read.Bytes(), err
+	}
+}
+
+// bytesDecoder decodes data. The kept payload is a copy, so the caller may reuse data.
+func bytesDecoder(data []byte) decoder {
+	return func(v any, keep bool) ([]byte, error) {
+		if !keep {
+			return nil, json.Unmarshal(data, v)
+		}
+		return bytes.Clone(data), json.Unmarshal(data, v)
+	}
+}
+
+func parseWithDecoder(decode decoder, to Schema, mode parseMode) error {
+	presence, err := decodeSchema(decode, to, mode.partial)
+	if err != nil {
+		return &ParseError{ParseError: decodeError(err, to)}
 	}
 
-	pipeSet, err := to.Rules()
+	pipeSet, err := rulesOf(to)
 	if err != nil {
 		return &ParseError{PreError: err}
 	}
@@ -104,18 +185,38 @@ func parseWithDecoder(decode func(any) error, to Schema, full bool) error {
 		return nil
 	}
 
-	if full {
-		schemaErrors := pipeSet.ValidateAll()
-		if schemaErrors == nil {
-			return nil
-		}
-		return &ParseError{ValidationError: schemaErrors}
-	}
-
-	schemaError := pipeSet.Validate()
+	schemaError := runSet(pipeSet, runContext{all: mode.full, partial: mode.partial, presence: presence})
 	if schemaError == nil {
 		return nil
 	}
 
 	return &ParseError{ValidationError: schemaError}
 }
+
+// decodeSchema decodes into to. For a [PresenceAware] schema, or any schema
+// when track is set, the payload is kept so the sent JSON paths can be
+// recorded. The paths are only walked the first time they are asked for, e.g.
+// by [Include.Present] or [Nullable], so rules that never look at presence
+// don't pay for it. It returns the presence, nil if not tracked.
+func decodeSchema(decode decoder, to Schema, track bool) (*lazyPresence, error) {
+	source, lazy := to.(presenceSource)
+	aware, ok := to.(PresenceAware)
+	if !lazy && !ok && !track {
+		_, err := decode(to, false)
+		return nil, err
+	}
+
+	raw, err := decode(to, true)
+	if err != nil {
+		return nil, err
+	}
+
+	presence := &lazyPresence{raw: raw}
+	switch {
+	case lazy:
+		source.setPayloadPresence(presence)
+	case ok:
+		aware.SetPresence(presence.get())
+	}
+	return presence, nil
+}
@@ -0,0 +1,103 @@
+package v
+
+import (
+	"io"
+)
+
+// Always marks pipe to run in partial validation even when its field is absent
+// from the payload. Use it for rules that must hold on every update, such as
+// cross-field rules.
+//
+// Example:
+//
+//	"updated_at": v.Always(v.TimePipe(u.UpdatedAt, v.NotEmptyDate())),
+func Always(pipe PipeFace) PipeFace {
+	return &alwaysPipe{pipe: pipe}
+}
+
+// alwaysPipe is a pipe that partial validation never skips.
+type alwaysPipe struct {
+	pipe PipeFace
+}
+
+// setKey sets the validation key for this pipe and the wrapped pipe.
+func (p *alwaysPipe) setKey(k string) {
+	p.pipe.setKey(k)
+}
+
+// Key returns the validation key associated with this pipe.
+func (p *alwaysPipe) Key() string {
+	return p.pipe.Key()
+}
+
+// Validate runs the wrapped pipe.
+func (p *alwaysPipe) Validate() error {
+	return p.validateWith(runContext{})
+}
+
+func (p *alwaysPipe) validateWith(ctx runContext) error {
+	return runPipe(p.pipe, ctx)
+}
+
+// ValidatePartial validates only the pipes whose field is in present, for
+// PATCH-style updates where untouched fields must not fail NotEmpty() or
+// NonZero(). Nested schemas are filtered the same way, e.g. with "address"
+// and "address.zip" present only the zip of the address is validated.
+// Pipes wrapped with [Always] and pipes without a key always run.
+//
+// It returns the first error, see [ValidateAllPartial] for all of them.
+func ValidatePartial(s Schema, present Presence) error {
+	rules, err := rulesOf(s)
+	if err != nil {
+		return NewPipeError(preCheckKey, err)
+	}
+	if rules == nil {
+		return nil
+	}
+	return runSet(rules, partialContext(present, false))
+}
+
+// ValidateAllPartial is like [ValidatePartial] but returns all errors as [ValidationErrors].
+func ValidateAllPartial(s Schema, present Presence) error {
+	rules, err := rulesOf(s)
+	if err != nil {
+		return ValidationErrors{NewPipeError(preCheckKey, err)}
+	}
+	if rules == nil {
+		return nil
+	}
+	return runSet(rules, partialContext(present, true))
+}
+
+func partialContext(present Presence, all bool) runContext {
+	if present == nil {
+		present = Presence{}
+	}
+	return runContext{all: all, partial: true, presence: knownPresence(present)}
+}
+
+// ParsePartial a schema from [io.Reader] and Validate only the fields present
+// in the payload, see [ValidatePartial].
+//
+// ParsePartial will return only one error which occur first.
+func ParsePartial(reader io.Reader, to Schema) error {
+	return parseWithDecoder(readerDecoder(reader), to, parseMode{partial: true})
+}
+
+// ParsePartialFull is like [ParsePartial] but returns full list of errors.
+func ParsePartialFull(reader io.Reader, to Schema) error {
+	return parseWithDecoder(readerDecoder(reader), to, parseMode{full: true, partial: true})
+}
+
+// ParseBytesPartial a schema from []bytes and Validate only the fields present
+// in the payload, see [ValidatePartial].
+//
+// ParseBytesPartial will return only one error which occur first.
+func ParseBytesPartial(data []byte, to Schema) error {
+	return parseWithDecoder(bytesDecoder(data), to, parseMode{partial: true})
+}
+
+// ParseBytesPartialFull is like [ParseBytesPartial] but returns full list of errors.
+func ParseBytesPartialFull(data []byte, to Schema) error {
+	return parseWithDecoder(bytesDecoder(data), to, parseMode{full: true, partial: true})
+}
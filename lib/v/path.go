@@ -0,0 +1,217 @@
+package v
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SegmentKind tells what a [PathSegment] points at.
+type SegmentKind uint8
+
+const (
+	// KeySegment is an object field, e.g. the "city" in address.city.
+	KeySegment SegmentKind = iota
+	// IndexSegment is a slice or array element, e.g. the 3 in recipients[3].
+	IndexSegment
+	// MapKeySegment is an entry of a dynamic map, e.g. the "region" in metadata["region"].
+	MapKeySegment
+)
+
+// PathSegment is one step in the location of a [PipeError], such as the
+// "address" or "city" in address.city, the 3 in recipients[3] or the
+// "region" in metadata["region"].
+type PathSegment struct {
+	Kind  SegmentKind
+	Key   string
+	Index int
+}
+
+// PathKey returns the path segment of an object field.
+func PathKey(key string) PathSegment {
+	return PathSegment{Kind: KeySegment, Key: key}
+}
+
+// PathIndex returns the path segment of a slice or array element.
+func PathIndex(index int) PathSegment {
+	return PathSegment{Kind: IndexSegment, Index: index}
+}
+
+// PathMapKey returns the path segment of a map entry.
+func PathMapKey(key string) PathSegment {
+	return PathSegment{Kind: MapKeySegment, Key: key}
+}
+
+// Path is the structured location of a [PipeError] inside a (possibly nested) schema.
+type Path []PathSegment
+
+// String renders the path in dotted notation, e.g. address.city, items[3].sku
+// or metadata["region"]. It is the notation of [PipeError.Key].
+func (p Path) String() string {
+	var b strings.Builder
+	for i, seg := range p {
+		switch seg.Kind {
+		case IndexSegment:
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(seg.Index))
+			b.WriteByte(']')
+		case MapKeySegment:
+			b.WriteByte('[')
+			b.WriteString(strconv.Quote(seg.Key))
+			b.WriteByte(']')
+		default:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(seg.Key)
+		}
+	}
+	return b.String()
+}
+
+// pointerEscaper escapes a key for a JSON Pointer reference token.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Pointer renders the path as an RFC 6901 JSON Pointer, e.g. /items/3/sku.
+// The empty path is the empty pointer, the whole document.
+func (p Path) Pointer() string {
+	var b strings.Builder
+	for _, seg := range p {
+		b.WriteByte('/')
+		if seg.Kind == IndexSegment {
+			b.WriteString(strconv.Itoa(seg.Index))
+			continue
+		}
+		b.WriteString(pointerEscaper.Replace(seg.Key))
+	}
+	return b.String()
+}
+
+// Form renders the path in the notation of form field names, e.g. items.3.sku
+// or metadata.region.
+func (p Path) Form() string {
+	var b strings.Builder
+	for i, seg := range p {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		if seg.Kind == IndexSegment {
+			b.WriteString(strconv.Itoa(seg.Index))
+			continue
+		}
+		b.WriteString(seg.Key)
+	}
+	return b.String()
+}
+
+// DecodeErrorPath returns the path of the payload field an encoding/json error
+// is about, such as a *json.UnmarshalTypeError for a string sent to an int field.
+// The dotted Field of the error is resolved against the type of target, so
+// slice indices and map keys become [PathIndex] and [PathMapKey] segments.
+// It returns false for errors without a field, such as syntax errors.
+//
+// The Parse helpers report these errors as a [DecodeError].
+func DecodeErrorPath(err error, target any) (Path, bool) {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field == "" {
+		return nil, false
+	}
+	return fieldPath(strings.Split(typeErr.Field, "."), reflect.TypeOf(target)), true
+}
+
+// fieldPath resolves the segments of a dotted decoder field against type t.
+func fieldPath(segs []string, t reflect.Type) Path {
+	var p Path
+	for i := 0; i < len(segs); i++ {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		switch {
+		case t == nil:
+			p = append(p, PathKey(segs[i]))
+		case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+			t = t.Elem()
+			n, err := strconv.Atoi(segs[i])
+			if err != nil {
+				// the field has no index, the segment belongs to the element
+				i--
+				continue
+			}
+			p = append(p, PathIndex(n))
+		case t.Kind() == reflect.Map:
+			// map keys may contain dots, so the key runs up to the next field of the value
+			end := i + 1
+			for end < len(segs) && !hasJSONField(t.Elem(), segs[end]) {
+				end++
+			}
+			if end == len(segs) && isStruct(t.Elem()) {
+				end = i + 1
+			}
+			p = append(p, PathMapKey(strings.Join(segs[i:end], ".")))
+			t = t.Elem()
+			i = end - 1
+		case t.Kind() == reflect.Struct:
+			field, ok := jsonField(t, segs[i])
+			t = nil
+			if ok {
+				t = field.Type
+			}
+			p = append(p, PathKey(segs[i]))
+		default:
+			p = append(p, PathKey(segs[i]))
+			t = nil
+		}
+	}
+	return p
+}
+
+func isStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+func hasJSONField(t reflect.Type, name string) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := jsonField(t, name)
+	return ok
+}
+
+// jsonField returns the field of struct type t decoded from the JSON name,
+// including the fields of embedded structs.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if tag == "" && f.Anonymous {
+			if ft := f.Type; isStruct(ft) {
+				for ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if field, ok := jsonField(ft, name); ok {
+					return field, true
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if tag == name || tag == "" && strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
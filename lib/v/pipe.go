@@ -0,0 +1,151 @@
+package v
+
+// Action is the interface for a validation step over a value of type T.
+// Every typed action (StringPipeAction, IntPipeAction, ...) is an Action,
+// so actions for your own domain types plug into the same [Pipe] engine.
+type Action[T any] interface {
+	Run(v T) error
+}
+
+// ActionFunc adapts a plain function to an [Action].
+//
+// Example:
+//
+//	notAdmin := v.ActionFunc[string](func(s string) error {
+//	    if s == "admin" {
+//	        return errors.New("reserved name")
+//	    }
+//	    return nil
+//	})
+type ActionFunc[T any] func(v T) error
+
+// Run calls f(value).
+func (f ActionFunc[T]) Run(value T) error {
+	return f(value)
+}
+
+// Pipe is the generic validation pipeline for a value of type T.
+// It runs its actions in sequence and wraps the first failure in a [PipeError].
+type Pipe[T any] struct {
+	actions []Action[T]
+	// plain is set when the actions only validate, see [plainActions].
+	plain  bool
+	value  T
+	key    string
+	target *T
+}
+
+// NewPipe creates a new validation pipe for a value of any type.
+// The pipe executes the provided actions in sequence during validation.
+//
+// Example:
+//
+//	pipe := v.NewPipe(Money{Amount: 10, Currency: "EUR"}, ValidCurrency(), PositiveAmount())
+func NewPipe[T any](value T, actions ...Action[T]) *Pipe[T] {
+	return &Pipe[T]{
+		value:   value,
+		actions: actions,
+		plain:   plainActions(actions),
+	}
+}
+
+// plainActions reports whether actions only validate: none of them rewrites
+// the value like [Trim] or needs the run settings like [Each]. Pipes classify
+// their actions once when they are built, so running plain actions needs no
+// interface assertion per value.
+func plainActions[T any](actions []Action[T]) bool {
+	for _, action := range actions {
+		switch action.(type) {
+		case transformer[T], contextAction[T]:
+			return false
+		}
+	}
+	return true
+}
+
+// setKey sets the validation key for this pipe.
+// This key is used in error messages to identify which field failed validation.
+func (pipe *Pipe[T]) setKey(k string) {
+	pipe.key = k
+}
+
+// Key returns the validation key associated with this pipe.
+func (pipe *Pipe[T]) Key() string {
+	return pipe.key
+}
+
+// Value returns the value this pipe validates, rewritten by its transform
+// actions such as [Trim] or [Clamp].
+func (pipe *Pipe[T]) Value() T {
+	if pipe.plain {
+		return pipe.value
+	}
+	value := pipe.value
+	for _, action := range pipe.actions {
+		if t, ok := action.(transformer[T]); ok {
+			value = t.transform(value)
+		}
+	}
+	return value
+}
+
+// Bind writes the transformed value into target once the pipe passes,
+// e.g. to store the trimmed value in the struct filled by [Parse].
+//
+// Example:
+//
+//	"email": v.StringPipe(u.Email, v.Trim(), v.ToLower(), v.IsEmail()).Bind(&u.Email),
+func (pipe *Pipe[T]) Bind(target *T) *Pipe[T] {
+	pipe.target = target
+	return pipe
+}
+
+// Validate runs all validation actions in sequence.
+// Returns a PipeError if any action fails, otherwise returns nil.
+func (pipe *Pipe[T]) Validate() error {
+	return pipe.validateWith(runContext{})
+}
+
+// ValidateAll runs all validation actions in sequence and, for actions that
+// validate nested values such as [Each], collects every nested error.
+func (pipe *Pipe[T]) ValidateAll() error {
+	return pipe.validateWith(runContext{all: true})
+}
+
+func (pipe *Pipe[T]) validateWith(ctx runContext) error {
+	if pipe.plain {
+		for _, action := range pipe.actions {
+			if err := action.Run(pipe.value); err != nil {
+				return pipe.fail(err, ctx)
+			}
+		}
+		if pipe.target != nil {
+			*pipe.target = pipe.value
+		}
+		return nil
+	}
+
+	actionCtx := ctx.at(keyPath(pipe.key)...)
+	value := pipe.value
+	for _, action := range pipe.actions {
+		if t, ok := action.(transformer[T]); ok {
+			value = t.transform(value)
+			continue
+		}
+		if err := runAction(action, value, actionCtx); err != nil {
+			return pipe.fail(err, ctx)
+		}
+	}
+	if pipe.target != nil {
+		*pipe.target = value
+	}
+	return nil
+}
+
+// fail reports the error of an action under the key of the pipe.
+func (pipe *Pipe[T]) fail(err error, ctx runContext) error {
+	if isLocated(err) {
+		return nestErrors(err, ctx, keyPath(pipe.key)...)
+	}
+	return NewPipeError(pipe.key, err)
+}
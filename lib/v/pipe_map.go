@@ -1,50 +1,51 @@
 package v
 
+import (
+	"maps"
+	"slices"
+)
+
 // PipeMap is a map of pipe keys to pipes.
+//
+// PipeMap validates its pipes in sorted key order, so [PipeMap.Validate] always
+// reports the same first failure for the same input. Use [NewPipesMap] with
+// [WithKeyOrder] or [WithKeySort] for a different order.
 type PipeMap map[string]PipeFace
 
-func (m PipeMap) ValidateAll() error {
-	return m.validateAllSequential()
+// pipes returns the pipes of the map in sorted key order.
+//
+// since in v.PipeMap value is pipe and while individual is validation time
+// key is not accessible, the map key is set on every pipe here so that
+// errors, including nested ones, are reported under it.
+func (m PipeMap) pipes() []PipeFace {
+	keys := slices.Sorted(maps.Keys(m))
+	pipes := make([]PipeFace, len(keys))
+	for i, key := range keys {
+		pipe := m[key]
+		pipe.setKey(key)
+		pipes[i] = pipe
+	}
+	return pipes
 }
 
-func (m PipeMap) validateAllSequential() error {
-	var validationErrors ValidationErrors
-
-	for key, pipe := range m {
-		if err := pipe.Validate(); err != nil {
-			if fieldErr, ok := err.(*PipeError); ok {
-				// since in v.PipeMap value is pipe and while individual is validation time
-				// key is not accessible so it return PipeError with our key.
-				// thats why here setting key manually.
-				fieldErr.Key = key
-
-				validationErrors = append(validationErrors, fieldErr)
+func (m PipeMap) ValidateAll() error {
+	return m.validateWith(runContext{all: true})
+}
 
-			} else {
-				validationErrors = append(validationErrors, NewPipeError(key, err))
-			}
-		}
-	}
+// ValidateAllParallel validates all pipes of the map concurrently.
+// See [PipeRegistry.ValidateAllParallel] for details.
+func (m PipeMap) ValidateAllParallel(options ...ParallelOption) error {
+	return m.validateParallelWith(runContext{all: true}, newParallelConfig(options))
+}
 
-	if len(validationErrors) > 0 {
-		return validationErrors
-	}
-	return nil
+func (m PipeMap) validateParallelWith(ctx runContext, cfg parallelConfig) error {
+	return validatePipesParallel(m.pipes(), ctx, cfg)
 }
 
 func (m PipeMap) Validate() error {
-	for key, pipe := range m {
-		if err := pipe.Validate(); err != nil {
-			if fieldErr, ok := err.(*PipeError); ok {
-				// since in v.PipeMap value is pipe and while individual is validation time
-				// key is not accessible so it return PipeError with our key.
-				// thats why here setting key manually.
-				fieldErr.Key = key
-
-				return fieldErr
-			}
-			return NewPipeError(key, err)
-		}
-	}
-	return nil
+	return m.validateWith(runContext{})
+}
+
+func (m PipeMap) validateWith(ctx runContext) error {
+	return validatePipes(m.pipes(), ctx)
 }
@@ -0,0 +1,157 @@
+package v
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// Plural categories of the Unicode CLDR plural rules, used as the keys
+// of plural messages in a [Catalog].
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralRule returns the plural category of the count n in a language.
+type PluralRule func(n float64) string
+
+// pluralRules holds the plural rules by language subtag. Languages without a
+// rule use the English one.
+var pluralRules sync.Map
+
+func init() {
+	for lang, rule := range map[string]PluralRule{
+		"en": pluralOneOther,
+		"bn": pluralZeroOrOne,
+		"hi": pluralZeroOrOne,
+		"fa": pluralZeroOrOne,
+		"fr": pluralFrench,
+		"pt": pluralFrench,
+		"ru": pluralSlavic,
+		"uk": pluralSlavic,
+		"be": pluralSlavic,
+		"pl": pluralPolish,
+		"cs": pluralCzech,
+		"sk": pluralCzech,
+		"ar": pluralArabic,
+		"ja": pluralNone,
+		"ko": pluralNone,
+		"zh": pluralNone,
+		"th": pluralNone,
+		"vi": pluralNone,
+		"id": pluralNone,
+	} {
+		pluralRules.Store(lang, rule)
+	}
+}
+
+// RegisterPluralRule sets the plural rule of a language, e.g. "tr", replacing
+// the built-in one if any.
+func RegisterPluralRule(lang string, rule PluralRule) {
+	pluralRules.Store(strings.ToLower(lang), rule)
+}
+
+// pluralCategory returns the plural category of n in the language of locale.
+func pluralCategory(locale string, n float64) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	if rule, ok := pluralRules.Load(strings.ToLower(lang)); ok {
+		return rule.(PluralRule)(n)
+	}
+	return pluralOneOther(n)
+}
+
+func isInteger(n float64) bool {
+	return n == math.Trunc(n)
+}
+
+func pluralNone(float64) string {
+	return PluralOther
+}
+
+func pluralOneOther(n float64) string {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralZeroOrOne is the rule of Bengali and Hindi: one for 0 and 1.
+func pluralZeroOrOne(n float64) string {
+	if math.Abs(n) < 1 && isInteger(n) || n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralFrench is one for 0 and 1, including fractions below 2.
+func pluralFrench(n float64) string {
+	if math.Abs(n) < 2 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralSlavic(n float64) string {
+	if !isInteger(n) {
+		return PluralOther
+	}
+	i := int64(math.Abs(n))
+	switch {
+	case i%10 == 1 && i%100 != 11:
+		return PluralOne
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+func pluralPolish(n float64) string {
+	if !isInteger(n) {
+		return PluralOther
+	}
+	i := int64(math.Abs(n))
+	switch {
+	case i == 1:
+		return PluralOne
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+func pluralCzech(n float64) string {
+	switch {
+	case !isInteger(n):
+		return PluralMany
+	case n == 1:
+		return PluralOne
+	case n >= 2 && n <= 4:
+		return PluralFew
+	}
+	return PluralOther
+}
+
+func pluralArabic(n float64) string {
+	if !isInteger(n) {
+		return PluralOther
+	}
+	i := int64(math.Abs(n))
+	switch {
+	case i == 0:
+		return PluralZero
+	case i == 1:
+		return PluralOne
+	case i == 2:
+		return PluralTwo
+	case i%100 >= 3 && i%100 <= 10:
+		return PluralFew
+	case i%100 >= 11:
+		return PluralMany
+	}
+	return PluralOther
+}
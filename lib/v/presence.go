@@ -0,0 +1,212 @@
+package v
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Presence is the set of JSON paths that were actually sent in a payload,
+// in dotted notation: "age", "address", "address.city", "items[0].sku".
+//
+// A field sent as null is present. Map entries are recorded like object
+// fields, e.g. "metadata.region".
+type Presence map[string]struct{}
+
+// NewPresence creates a [Presence] from dotted paths.
+func NewPresence(paths ...string) Presence {
+	p := make(Presence, len(paths))
+	for _, path := range paths {
+		p[path] = struct{}{}
+	}
+	return p
+}
+
+// Has reports whether path was present in the payload.
+func (p Presence) Has(path string) bool {
+	_, ok := p[path]
+	return ok
+}
+
+// Paths returns all present paths in sorted order.
+func (p Presence) Paths() []string {
+	paths := make([]string, 0, len(p))
+	for path := range p {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// hasPath reports whether the structured path was present in the payload.
+func (p Presence) hasPath(path Path) bool {
+	return p.Has(presenceKey(path))
+}
+
+// presenceKey renders path the way [Presence] records it:
+// dotted notation with map entries written like object fields.
+func presenceKey(path Path) string {
+	var b strings.Builder
+	for i, seg := range path {
+		if seg.Kind == IndexSegment {
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(seg.Index))
+			b.WriteByte(']')
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg.Key)
+	}
+	return b.String()
+}
+
+// PresenceAware is implemented by schemas that want to know which JSON fields
+// were sent. Embedding [Include] implements it; the Parse helpers fill it in
+// before calling [Schema.Rules].
+type PresenceAware interface {
+	SetPresence(p Presence)
+	Presence() Presence
+}
+
+// presenceSource is implemented by [Include], which takes the presence from
+// the Parse helpers without walking the payload until it is asked for.
+type presenceSource interface {
+	setPayloadPresence(presence *lazyPresence)
+	payloadPresence() *lazyPresence
+}
+
+// presenceOf returns the presence recorded on s, or nil if s doesn't track it.
+func presenceOf(s Schema) *lazyPresence {
+	if source, ok := s.(presenceSource); ok {
+		return source.payloadPresence()
+	}
+	if aware, ok := s.(PresenceAware); ok {
+		if p := aware.Presence(); p != nil {
+			return knownPresence(p)
+		}
+	}
+	return nil
+}
+
+// lazyPresence records the paths of a raw JSON document the first time
+// they are asked for. It is safe for concurrent use.
+type lazyPresence struct {
+	once     sync.Once
+	raw      []byte
+	presence Presence
+}
+
+// knownPresence returns the lazyPresence of paths that are already recorded.
+func knownPresence(p Presence) *lazyPresence {
+	return &lazyPresence{presence: p}
+}
+
+// get returns the recorded paths, nil for a nil l.
+func (l *lazyPresence) get() Presence {
+	if l == nil {
+		return nil
+	}
+	l.once.Do(func() {
+		if l.raw == nil {
+			return
+		}
+		// raw was already decoded into the schema, so it starts with valid JSON.
+		l.presence, _ = decodePresence(l.raw)
+		l.raw = nil
+	})
+	return l.presence
+}
+
+// decodePresence records every path present in the first JSON value of data.
+func decodePresence(data []byte) (Presence, error) {
+	var doc any
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return nil, err
+	}
+	p := Presence{}
+	p.walk(nil, doc)
+	return p, nil
+}
+
+func (p Presence) walk(path Path, node any) {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, child := range n {
+			childPath := append(path[:len(path):len(path)], PathKey(key))
+			p[presenceKey(childPath)] = struct{}{}
+			p.walk(childPath, child)
+		}
+	case []any:
+		for i, child := range n {
+			childPath := append(path[:len(path):len(path)], PathIndex(i))
+			p[presenceKey(childPath)] = struct{}{}
+			p.walk(childPath, child)
+		}
+	}
+}
+
+// presenceCheck is the outcome of looking a pipe up in the run presence.
+type presenceCheck uint8
+
+const (
+	presenceUnknown presenceCheck = iota
+	presenceAbsent
+	presencePresent
+)
+
+// IfPresent runs pipe only if its field was sent in the payload, so
+// {"age":0} is validated while {} is not. When presence is unknown, e.g.
+// the schema wasn't filled by a Parse helper, the pipe always runs.
+//
+// Example:
+//
+//	"age": v.IfPresent(v.IntPipe(u.Age, v.Min(18))),
+func IfPresent(pipe PipeFace) PipeFace {
+	return &presencePipe{pipe: pipe}
+}
+
+// RequirePresent fails with [ErrRequired] if the field of pipe wasn't sent
+// in the payload and runs pipe otherwise, so {"age":0} passes the presence
+// check while {} does not. When presence is unknown the pipe always runs.
+func RequirePresent(pipe PipeFace) PipeFace {
+	return &presencePipe{pipe: pipe, required: true}
+}
+
+// presencePipe runs its pipe depending on whether its field was sent.
+type presencePipe struct {
+	pipe     PipeFace
+	required bool
+}
+
+// setKey sets the validation key for this pipe and the wrapped pipe.
+func (p *presencePipe) setKey(k string) {
+	p.pipe.setKey(k)
+}
+
+// Key returns the validation key associated with this pipe.
+func (p *presencePipe) Key() string {
+	return p.pipe.Key()
+}
+
+// Validate runs the wrapped pipe. Without a Parse helper presence is unknown,
+// so the pipe always runs.
+func (p *presencePipe) Validate() error {
+	return p.validateWith(runContext{})
+}
+
+func (p *presencePipe) validateWith(ctx runContext) error {
+	switch ctx.lookup(p.pipe.Key()) {
+	case presenceAbsent:
+		if p.required {
+			return NewPipeError(p.pipe.Key(), requiredError())
+		}
+		return nil
+	default:
+		return runPipe(p.pipe, ctx)
+	}
+}
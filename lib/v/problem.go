@@ -0,0 +1,174 @@
+package v
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details document. InvalidParams is the
+// invalid-params extension listing every field that failed validation.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam is an entry of the invalid-params extension. Name is the key
+// of the field, e.g. items[3].sku, Pointer its JSON Pointer, e.g. /items/3/sku,
+// and Code the code of the failed action, if any, see [ActionError].
+type InvalidParam struct {
+	Name    string `json:"name"`
+	Pointer string `json:"pointer"`
+	Code    string `json:"code,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// problemConfig holds the settings of a problem document.
+type problemConfig struct {
+	typeURI  string
+	title    string
+	status   int
+	instance string
+}
+
+// ProblemOption configures [NewProblem] and [WriteProblem].
+type ProblemOption func(*problemConfig)
+
+// ProblemType sets the type URI of the problem, "about:blank" by default.
+func ProblemType(uri string) ProblemOption {
+	return func(c *problemConfig) {
+		c.typeURI = uri
+	}
+}
+
+// ProblemTitle sets the title of the problem. It defaults to the text of the
+// status code, e.g. Unprocessable Entity.
+func ProblemTitle(title string) ProblemOption {
+	return func(c *problemConfig) {
+		c.title = title
+	}
+}
+
+// ProblemStatus sets the status of validation failures, 422 by default.
+// Payloads that can't be decoded are 400 and failing [Schema.Rules] 500 regardless.
+func ProblemStatus(status int) ProblemOption {
+	return func(c *problemConfig) {
+		c.status = status
+	}
+}
+
+// ProblemInstance sets the URI of this occurrence of the problem, e.g. the request path.
+func ProblemInstance(uri string) ProblemOption {
+	return func(c *problemConfig) {
+		c.instance = uri
+	}
+}
+
+// NewProblem renders an error returned by [Validate], [ValidateAll], a pipe set
+// or a Parse helper as RFC 9457 problem details. Every [PipeError] becomes an
+// entry of invalid-params. Localize err first to render it in the caller's language.
+//
+// Example:
+//
+//	if err := v.ParseFull(r.Body, &user); err != nil {
+//	    v.WriteProblem(w, err, v.ProblemType("https://example.com/problems/validation"))
+//	    return
+//	}
+func NewProblem(err error, options ...ProblemOption) *Problem {
+	cfg := problemConfig{typeURI: "about:blank", status: http.StatusUnprocessableEntity}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	problem := &Problem{Type: cfg.typeURI, Status: cfg.status, Instance: cfg.instance}
+	fillProblem(problem, err)
+
+	problem.Title = cfg.title
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	return problem
+}
+
+// decodeReason describes the JSON type the field of err expects.
+func decodeReason(err *DecodeError) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Type != nil {
+		if jsonType, ok := typeSchema(typeErr.Type)["type"].(string); ok {
+			return "must be of type " + jsonType
+		}
+	}
+	return "has the wrong type"
+}
+
+// fillProblem sets the status, detail and invalid params of err.
+func fillProblem(problem *Problem, err error) {
+	switch e := err.(type) {
+	case ValidationErrors:
+		if len(e) == 1 && e[0].Key == preCheckKey {
+			problem.Status = http.StatusInternalServerError
+			return
+		}
+		for _, pipeErr := range e {
+			problem.InvalidParams = append(problem.InvalidParams, invalidParam(pipeErr))
+		}
+	case *PipeError:
+		if e.Key == preCheckKey {
+			problem.Status = http.StatusInternalServerError
+			return
+		}
+		problem.InvalidParams = []InvalidParam{invalidParam(e)}
+	case *ParseError:
+		switch {
+		case e.ParseError != nil:
+			// the decoder's text names Go types and fields, so it stays out of the response
+			problem.Status = http.StatusBadRequest
+			problem.Detail = "request body could not be decoded"
+			var decodeErr *DecodeError
+			if errors.As(e.ParseError, &decodeErr) {
+				problem.InvalidParams = []InvalidParam{{
+					Name:    decodeErr.Path.String(),
+					Pointer: decodeErr.Path.Pointer(),
+					Reason:  decodeReason(decodeErr),
+				}}
+			}
+		case e.PreError != nil:
+			problem.Status = http.StatusInternalServerError
+		case e.ValidationError != nil:
+			fillProblem(problem, e.ValidationError)
+		case e.PostError != nil:
+			problem.Detail = e.PostError.Error()
+		}
+	case nil:
+	default:
+		problem.Detail = err.Error()
+	}
+}
+
+func invalidParam(e *PipeError) InvalidParam {
+	path := e.Path
+	if len(path) == 0 {
+		path = keyPath(e.Key)
+	}
+	param := InvalidParam{Name: e.Key, Pointer: path.Pointer(), Reason: e.message()}
+	if actionErr := actionErrorOf(e.Err); actionErr != nil {
+		param.Code = actionErr.Code
+	}
+	return param
+}
+
+// WriteProblem writes the problem details of err to w with the
+// application/problem+json content type and the status of the problem.
+func WriteProblem(w http.ResponseWriter, err error, options ...ProblemOption) error {
+	problem := NewProblem(err, options...)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	return json.NewEncoder(w).Encode(problem)
+}
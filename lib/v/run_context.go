@@ -0,0 +1,150 @@
+package v
+
+// runContext carries the settings of one validation run from a [PipeSet]
+// down to its pipes, including pipes that hold nested pipe sets.
+type runContext struct {
+	// all collects every error instead of stopping at the first one.
+	all bool
+	// presence holds the JSON paths sent in the payload, nil if unknown.
+	presence *lazyPresence
+	// partial skips the pipes whose field is absent from presence.
+	partial bool
+	// fields are the sibling pipes of the current pipe set.
+	fields Fields
+	// path is the location of the current pipe set, tracked only when
+	// presence is known.
+	path Path
+}
+
+// at returns the context for values located at the given segments below ctx.
+func (ctx runContext) at(segs ...PathSegment) runContext {
+	if ctx.presence == nil || len(segs) == 0 {
+		return ctx
+	}
+	child := ctx
+	child.path = append(ctx.path[:len(ctx.path):len(ctx.path)], segs...)
+	return child
+}
+
+// withFields returns the context for validating the given sibling pipes.
+func (ctx runContext) withFields(pipes []PipeFace) runContext {
+	child := ctx
+	child.fields = Fields{pipes: pipes}
+	return child
+}
+
+// lookup tells whether the field key of the current pipe set was sent.
+func (ctx runContext) lookup(key string) presenceCheck {
+	if ctx.presence == nil {
+		return presenceUnknown
+	}
+	presence := ctx.presence.get()
+	if presence == nil {
+		return presenceUnknown
+	}
+	if presence.hasPath(ctx.at(keyPath(key)...).path) {
+		return presencePresent
+	}
+	return presenceAbsent
+}
+
+// contextPipe is implemented by pipes that need the run settings,
+// typically because they validate a nested [PipeSet].
+type contextPipe interface {
+	validateWith(ctx runContext) error
+}
+
+// contextSet is implemented by pipe sets that can be validated with
+// the settings of an enclosing run.
+type contextSet interface {
+	validateWith(ctx runContext) error
+}
+
+// runPipe validates pipe within ctx.
+func runPipe(pipe PipeFace, ctx runContext) error {
+	if cp, ok := pipe.(contextPipe); ok {
+		return cp.validateWith(ctx)
+	}
+	return pipe.Validate()
+}
+
+// runSet validates set within ctx.
+func runSet(set PipeSet, ctx runContext) error {
+	if cs, ok := set.(contextSet); ok {
+		return cs.validateWith(ctx)
+	}
+	if ctx.all {
+		return set.ValidateAll()
+	}
+	return set.Validate()
+}
+
+// skip reports whether pipe is left out of a partial run because its field wasn't sent.
+// Pipes without a key and pipes wrapped with [Always] are never skipped.
+func (ctx runContext) skip(pipe PipeFace) bool {
+	if !ctx.partial || pipe.Key() == "" {
+		return false
+	}
+	if _, ok := pipe.(*alwaysPipe); ok {
+		return false
+	}
+	return ctx.lookup(pipe.Key()) == presenceAbsent
+}
+
+// collectErrors flattens the error of the pipe with the given key into [ValidationErrors].
+// Errors that already carry a location, such as the ones of nested pipe sets, are kept as they are.
+func collectErrors(key string, err error) ValidationErrors {
+	switch e := err.(type) {
+	case ValidationErrors:
+		return e
+	case *PipeError:
+		if e.Key == "" && len(e.Path) == 0 {
+			e.setPath(keyPath(key))
+		}
+		return ValidationErrors{e}
+	default:
+		return ValidationErrors{NewPipeError(key, err)}
+	}
+}
+
+// contextAction is implemented by actions that validate nested values,
+// such as [Each], and need the run settings to do so.
+type contextAction[T any] interface {
+	runWith(value T, ctx runContext) error
+}
+
+// runAction runs action on value within ctx.
+func runAction[T any](action Action[T], value T, ctx runContext) error {
+	if ca, ok := action.(contextAction[T]); ok {
+		return ca.runWith(value, ctx)
+	}
+	return action.Run(value)
+}
+
+// isLocated reports whether err already carries its own location,
+// i.e. it comes from a nested pipe or pipe set.
+func isLocated(err error) bool {
+	switch err.(type) {
+	case *PipeError, ValidationErrors:
+		return true
+	}
+	return false
+}
+
+// nestErrors places the errors of a nested run under the parent segments and
+// returns them the way ctx expects: only the first one, or all of them.
+func nestErrors(err error, ctx runContext, parent ...PathSegment) error {
+	errs := collectErrors("", err)
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(parent) > 0 {
+		for _, e := range errs {
+			e.prefix(parent...)
+		}
+	}
+	if !ctx.all {
+		return errs[0]
+	}
+	return errs
+}
@@ -0,0 +1,168 @@
+package v
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SliceAction defines the interface for actions that validate a whole slice.
+type SliceAction[T any] = Action[[]T]
+
+// SlicePipe creates a new validation pipe for a slice.
+// Collection actions such as [MinItems] or [UniqueItems] validate the slice itself,
+// [Each] and [EachPipe] validate every element. Element errors are keyed with
+// their index, e.g. recipients[3]. For arrays pass a slice of it (arr[:]).
+//
+// Example:
+//
+//	v.Entry("recipients").Pipe(v.SlicePipe(r.Recipients,
+//	    v.MinItems[string](1),
+//	    v.MaxItems[string](50),
+//	    v.Each(v.NotEmpty(), v.IsEmail()),
+//	))
+func SlicePipe[T any](values []T, actions ...SliceAction[T]) *Pipe[[]T] {
+	return NewPipe(values, actions...)
+}
+
+// eachAction runs element actions, or a pipe built per element, on every element of a slice.
+type eachAction[T any] struct {
+	actions []Action[T]
+	plain   bool
+	pipe    func(item T) PipeFace
+}
+
+// Each validates every element of a slice with the given actions.
+// Any existing action of the element type works, e.g. Each(v.IsEmail()) for []string.
+// With ValidateAll every failing element is reported, otherwise only the first one.
+func Each[T any](actions ...Action[T]) SliceAction[T] {
+	return &eachAction[T]{actions: actions, plain: plainActions(actions)}
+}
+
+// EachPipe validates every element of a slice with the pipe returned by build.
+// Use it to validate elements with a nested schema.
+//
+// Example:
+//
+//	v.EachPipe(func(item LineItem) v.PipeFace {
+//	    return v.SchemaPipe(&item)
+//	})
+func EachPipe[T any](build func(item T) PipeFace) SliceAction[T] {
+	return &eachAction[T]{pipe: build}
+}
+
+// Run validates the elements and stops at the first failing one.
+func (each *eachAction[T]) Run(values []T) error {
+	return each.runWith(values, runContext{})
+}
+
+func (each *eachAction[T]) runWith(values []T, ctx runContext) error {
+	var validationErrors ValidationErrors
+
+	for i, item := range values {
+		var pipe PipeFace
+		if each.pipe != nil {
+			pipe = each.pipe(item)
+		} else {
+			pipe = &Pipe[T]{value: item, actions: each.actions, plain: each.plain}
+		}
+
+		err := runPipe(pipe, ctx.at(PathIndex(i)))
+		if err == nil {
+			continue
+		}
+
+		if err := nestErrors(err, ctx, PathIndex(i)); err != nil {
+			if !ctx.all {
+				return err
+			}
+			validationErrors = append(validationErrors, err.(ValidationErrors)...)
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+// MinItems validates that a slice has at least min elements.
+// The optional ActionOptions parameter can be used to customize the error message.
+func MinItems[T any](min int, option ...ActionOptionFace) SliceAction[T] {
+	return &action[[]T]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "slice.min_items",
+				msg:    fmt.Sprintf("must contain at least %d items", min),
+				params: map[string]any{"min": min},
+				schema: JSONSchema{"minItems": min},
+			}
+		},
+		validate: func(v []T) bool {
+			return len(v) >= min
+		},
+	}
+}
+
+// MaxItems validates that a slice has at most max elements.
+// The optional ActionOptions parameter can be used to customize the error message.
+func MaxItems[T any](max int, option ...ActionOptionFace) SliceAction[T] {
+	return &action[[]T]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "slice.max_items",
+				msg:    fmt.Sprintf("must contain at most %d items", max),
+				params: map[string]any{"max": max},
+				schema: JSONSchema{"maxItems": max},
+			}
+		},
+		validate: func(v []T) bool {
+			return len(v) <= max
+		},
+	}
+}
+
+// UniqueItems validates that a slice has no duplicate elements.
+// The optional ActionOptions parameter can be used to customize the error message.
+func UniqueItems[T comparable](option ...ActionOptionFace) SliceAction[T] {
+	return &action[[]T]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "slice.unique_items",
+				msg:    "items must be unique",
+				schema: JSONSchema{"uniqueItems": true},
+			}
+		},
+		validate: func(v []T) bool {
+			seen := make(map[T]struct{}, len(v))
+			for _, item := range v {
+				if _, ok := seen[item]; ok {
+					return false
+				}
+				seen[item] = struct{}{}
+			}
+			return true
+		},
+	}
+}
+
+// ContainsItem validates that a slice contains the given element.
+// The optional ActionOptions parameter can be used to customize the error message.
+func ContainsItem[T comparable](item T, option ...ActionOptionFace) SliceAction[T] {
+	return &action[[]T]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "slice.contains",
+				msg:    fmt.Sprintf("must contain %v", item),
+				params: map[string]any{"item": item},
+				schema: JSONSchema{"contains": JSONSchema{"const": item}},
+			}
+		},
+		validate: func(v []T) bool {
+			return slices.Contains(v, item)
+		},
+	}
+}
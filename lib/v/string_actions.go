@@ -1,40 +1,14 @@
 package v
 
 import (
-	"fmt"
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/mrbns/valgo/lib/is"
 )
 
-// stringAction implements StringPipeAction for string validation.
-type stringAction struct {
-	errorMsg func(v string) string
-	validate func(v string) bool
-}
-
-// Run executes the validation function on the given string value.
-// Returns an error if validation fails.
-func (action *stringAction) Run(value string) error {
-	if !action.validate(value) {
-		return fmt.Errorf("%s", action.errorMsg(value))
-	}
-	return nil
-}
-
-// extractMsg extracts a custom error message from ActionOptions or returns the default message.
-func extractMsg(defaultMsg string, value any, option ...ActionOptionFace) string {
-	errMsg := defaultMsg
-	for _, op := range option {
-		if errInterface, ok := op.(CustomErrFace); ok {
-			errMsg = errInterface.Msg(value)
-		}
-	}
-	return errMsg
-}
-
 // CustomString creates a custom string validator using the provided validation function.
 // The optional ActionOptions parameter can be used to customize the error message.
 //
@@ -42,9 +16,13 @@ func extractMsg(defaultMsg string, value any, option ...ActionOptionFace) string
 //
 //	CustomString(func(v string) bool { return strings.HasPrefix(v, "test_") })
 func CustomString(fn func(value string) bool, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("invalid string", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.custom",
+				msg:  "invalid string",
+			}
 		},
 		validate: fn,
 	}
@@ -53,9 +31,15 @@ func CustomString(fn func(value string) bool, option ...ActionOptionFace) String
 // NotEmpty validates that a string is not empty.
 // The optional ActionOptions parameter can be used to customize the error message.
 func NotEmpty(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("cannot be empty", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:     "string.not_empty",
+				msg:      "cannot be empty",
+				schema:   JSONSchema{"minLength": 1},
+				required: true,
+			}
 		},
 		validate: func(v string) bool {
 			return v != ""
@@ -65,9 +49,15 @@ func NotEmpty(option ...ActionOptionFace) StringPipeAction {
 
 // Enum validate that a string includes from a set of string.
 func Enum(slice []string, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("value is not allowed", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.enum",
+				msg:    "value is not allowed",
+				params: map[string]any{"values": slice},
+				schema: JSONSchema{"enum": slice},
+			}
 		},
 		validate: func(v string) bool {
 			return slices.Contains(slice, v)
@@ -79,9 +69,15 @@ func Enum(slice []string, option ...ActionOptionFace) StringPipeAction {
 //
 // for case-insensitive checkout [EqualFold]
 func EqualString(cmp string, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("must be equal to "+cmp, v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.equal",
+				msg:    "must be equal to " + cmp,
+				params: map[string]any{"value": cmp},
+				schema: JSONSchema{"const": cmp},
+			}
 		},
 		validate: func(v string) bool {
 			return v == cmp
@@ -90,17 +86,24 @@ func EqualString(cmp string, option ...ActionOptionFace) StringPipeAction {
 }
 
 // Pattern validates that a string matches the provided regular expression pattern.
-// The regex is compiled once during validator creation for efficiency.
+// The regex is compiled once per pattern and shared by every Pattern action using it,
+// so building the action again in [Schema.Rules] doesn't recompile it.
 // The optional ActionOptions parameter can be used to customize the error message.
 //
 // Example:
 //
 //	Pattern(`^\d{3}-\d{2}-\d{4}$`) // SSN format
 func Pattern(regexStr string, option ...ActionOptionFace) StringPipeAction {
-	regex := regexp.MustCompile(regexStr)
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("string doesn't follow the pattern "+regexStr, v, option...)
+	regex := compilePattern(regexStr)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.pattern",
+				msg:    "string doesn't follow the pattern " + regexStr,
+				params: map[string]any{"pattern": regexStr},
+				schema: JSONSchema{"pattern": regexStr},
+			}
 		},
 		validate: func(v string) bool {
 			return regex.MatchString(v)
@@ -108,12 +111,29 @@ func Pattern(regexStr string, option ...ActionOptionFace) StringPipeAction {
 	}
 }
 
+// patterns caches the compiled regular expressions of Pattern.
+var patterns sync.Map
+
+func compilePattern(regexStr string) *regexp.Regexp {
+	if regex, ok := patterns.Load(regexStr); ok {
+		return regex.(*regexp.Regexp)
+	}
+	regex, _ := patterns.LoadOrStore(regexStr, regexp.MustCompile(regexStr))
+	return regex.(*regexp.Regexp)
+}
+
 // MaxLength validates that a string does not exceed the specified maximum length.
 // The optional ActionOptions parameter can be used to customize the error message.
 func MaxLength(max int, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("string length exceeds maximum", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.max_length",
+				msg:    "string length exceeds maximum",
+				params: map[string]any{"max": max},
+				schema: JSONSchema{"maxLength": max},
+			}
 		},
 		validate: func(v string) bool {
 			return is.IsMaxLength(v, max)
@@ -124,9 +144,15 @@ func MaxLength(max int, option ...ActionOptionFace) StringPipeAction {
 // MinLength validates that a string has at least the specified minimum length.
 // The optional ActionOptions parameter can be used to customize the error message.
 func MinLength(min int, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("string length must be at least specified minimum", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.min_length",
+				msg:    "string length must be at least specified minimum",
+				params: map[string]any{"min": min},
+				schema: JSONSchema{"minLength": min},
+			}
 		},
 		validate: func(v string) bool {
 			return is.IsMinLength(v, min)
@@ -137,9 +163,15 @@ func MinLength(min int, option ...ActionOptionFace) StringPipeAction {
 // HasPrefix validates that a string starts with the provided prefix.
 // The optional ActionOptions parameter can be used to customize the error message.
 func HasPrefix(prefix string, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("must start with "+prefix, v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.prefix",
+				msg:    "must start with " + prefix,
+				params: map[string]any{"prefix": prefix},
+				schema: JSONSchema{"pattern": "^" + regexp.QuoteMeta(prefix)},
+			}
 		},
 		validate: func(v string) bool {
 			return strings.HasPrefix(v, prefix)
@@ -150,9 +182,15 @@ func HasPrefix(prefix string, option ...ActionOptionFace) StringPipeAction {
 // HasSuffix validates that a string end with the provided prefix.
 // The optional ActionOptions parameter can be used to customize the error message.
 func HasSuffix(suffix string, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("must end with "+suffix, v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.suffix",
+				msg:    "must end with " + suffix,
+				params: map[string]any{"suffix": suffix},
+				schema: JSONSchema{"pattern": regexp.QuoteMeta(suffix) + "$"},
+			}
 		},
 		validate: func(v string) bool {
 			return strings.HasSuffix(v, suffix)
@@ -164,9 +202,14 @@ func HasSuffix(suffix string, option ...ActionOptionFace) StringPipeAction {
 // This comparison is case-insensitive and handles Unicode correctly.
 // The optional ActionOptions parameter can be used to customize the error message.
 func EqualFold(target string, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("must be equal to "+target+" (case-insensitive)", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.equal_fold",
+				msg:    "must be equal to " + target + " (case-insensitive)",
+				params: map[string]any{"value": target},
+			}
 		},
 		validate: func(v string) bool {
 			return strings.EqualFold(v, target)
@@ -177,9 +220,15 @@ func EqualFold(target string, option ...ActionOptionFace) StringPipeAction {
 // Contains validates that a string contains the provided substring.
 // The optional ActionOptions parameter can be used to customize the error message.
 func Contains(substr string, option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("must contain "+substr, v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.contains",
+				msg:    "must contain " + substr,
+				params: map[string]any{"substr": substr},
+				schema: JSONSchema{"pattern": regexp.QuoteMeta(substr)},
+			}
 		},
 		validate: func(v string) bool {
 			return strings.Contains(v, substr)
@@ -190,9 +239,13 @@ func Contains(substr string, option ...ActionOptionFace) StringPipeAction {
 // IsAlpha validates that a string contains only alphabetic characters.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsAlpha(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("must contain only alphabetic characters", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.alpha",
+				msg:  "must contain only alphabetic characters",
+			}
 		},
 		validate: is.IsAlpha,
 	}
@@ -201,9 +254,13 @@ func IsAlpha(option ...ActionOptionFace) StringPipeAction {
 // IsAlphaNumeric validates that a string contains only alphanumeric characters.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsAlphaNumeric(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("must contain only alphanumeric characters", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.alphanumeric",
+				msg:  "must contain only alphanumeric characters",
+			}
 		},
 		validate: is.IsAlphaNumeric,
 	}
@@ -212,9 +269,13 @@ func IsAlphaNumeric(option ...ActionOptionFace) StringPipeAction {
 // IsAscii validates that a string contains only ASCII characters.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsAscii(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("must contain only ASCII characters", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.ascii",
+				msg:  "must contain only ASCII characters",
+			}
 		},
 		validate: is.IsAscii,
 	}
@@ -223,9 +284,13 @@ func IsAscii(option ...ActionOptionFace) StringPipeAction {
 // IsBase32 validates that a string is valid base32-encoded data.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsBase32(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid base32 string", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.base32",
+				msg:  "not a valid base32 string",
+			}
 		},
 		validate: is.IsBase32,
 	}
@@ -234,9 +299,13 @@ func IsBase32(option ...ActionOptionFace) StringPipeAction {
 // IsBase58 validates that a string uses base58 encoding.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsBase58(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid base58 string", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.base58",
+				msg:  "not a valid base58 string",
+			}
 		},
 		validate: is.IsBase58,
 	}
@@ -245,9 +314,13 @@ func IsBase58(option ...ActionOptionFace) StringPipeAction {
 // IsBase64 validates that a string is valid base64-encoded data.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsBase64(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid base64 string", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.base64",
+				msg:  "not a valid base64 string",
+			}
 		},
 		validate: is.IsBase64,
 	}
@@ -256,9 +329,13 @@ func IsBase64(option ...ActionOptionFace) StringPipeAction {
 // IsBitcoinAddress validates that a string is a valid Bitcoin address.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsBitcoinAddress(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid Bitcoin address", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.bitcoin_address",
+				msg:  "not a valid Bitcoin address",
+			}
 		},
 		validate: is.IsBitcoinAddress,
 	}
@@ -267,9 +344,13 @@ func IsBitcoinAddress(option ...ActionOptionFace) StringPipeAction {
 // IsCreditCard validates that a string is a valid credit card number using the Luhn algorithm.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsCreditCard(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid credit card number", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.credit_card",
+				msg:  "not a valid credit card number",
+			}
 		},
 		validate: is.IsCreditCard,
 	}
@@ -278,9 +359,13 @@ func IsCreditCard(option ...ActionOptionFace) StringPipeAction {
 // IsDate validates that a string represents a date in YYYY-MM-DD format.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsDate(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid date", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.date",
+				msg:  "not a valid date",
+			}
 		},
 		validate: is.IsDate,
 	}
@@ -289,9 +374,13 @@ func IsDate(option ...ActionOptionFace) StringPipeAction {
 // IsDataURI validates that a string is a valid data URI.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsDataURI(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid data URI", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.data_uri",
+				msg:  "not a valid data URI",
+			}
 		},
 		validate: is.IsDataURI,
 	}
@@ -300,9 +389,13 @@ func IsDataURI(option ...ActionOptionFace) StringPipeAction {
 // IsDecimal validates that a string represents a valid decimal number.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsDecimal(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid decimal number", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.decimal",
+				msg:  "not a valid decimal number",
+			}
 		},
 		validate: is.IsDecimal,
 	}
@@ -312,9 +405,14 @@ func IsDecimal(option ...ActionOptionFace) StringPipeAction {
 // Uses Go's standard mail.ParseAddress for validation.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsEmail(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid email", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.email",
+				msg:    "not a valid email",
+				schema: JSONSchema{"format": "email"},
+			}
 		},
 		validate: is.IsEmail,
 	}
@@ -323,9 +421,13 @@ func IsEmail(option ...ActionOptionFace) StringPipeAction {
 // IsEvmAddress validates that a string is a valid Ethereum Virtual Machine address.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsEvmAddress(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid EVM address", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.evm_address",
+				msg:  "not a valid EVM address",
+			}
 		},
 		validate: is.IsEvmAddress,
 	}
@@ -334,9 +436,13 @@ func IsEvmAddress(option ...ActionOptionFace) StringPipeAction {
 // IsHTML validates that a string contains HTML tags.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsHTML(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid HTML string", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.html",
+				msg:  "not a valid HTML string",
+			}
 		},
 		validate: is.IsHTML,
 	}
@@ -345,9 +451,13 @@ func IsHTML(option ...ActionOptionFace) StringPipeAction {
 // IsHexColor validates that a string is a valid hexadecimal color code.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsHexColor(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid hex color", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.hex_color",
+				msg:  "not a valid hex color",
+			}
 		},
 		validate: is.IsHexColor,
 	}
@@ -356,9 +466,13 @@ func IsHexColor(option ...ActionOptionFace) StringPipeAction {
 // IsHexDecimal validates that a string contains only hexadecimal characters.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsHexDecimal(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid hexadecimal string", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.hexadecimal",
+				msg:  "not a valid hexadecimal string",
+			}
 		},
 		validate: is.IsHexDecimal,
 	}
@@ -367,9 +481,13 @@ func IsHexDecimal(option ...ActionOptionFace) StringPipeAction {
 // IsHSL validates that a string represents a valid HSL color.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsHSL(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid HSL color", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.hsl",
+				msg:  "not a valid HSL color",
+			}
 		},
 		validate: is.IsHSL,
 	}
@@ -378,9 +496,14 @@ func IsHSL(option ...ActionOptionFace) StringPipeAction {
 // IsIPV4 validates that a string is a valid IPv4 address.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsIPV4(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid IPv4 address", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.ipv4",
+				msg:    "not a valid IPv4 address",
+				schema: JSONSchema{"format": "ipv4"},
+			}
 		},
 		validate: is.IsIPV4,
 	}
@@ -389,9 +512,14 @@ func IsIPV4(option ...ActionOptionFace) StringPipeAction {
 // IsIPV6 validates that a string is a valid IPv6 address.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsIPV6(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid IPv6 address", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.ipv6",
+				msg:    "not a valid IPv6 address",
+				schema: JSONSchema{"format": "ipv6"},
+			}
 		},
 		validate: is.IsIPV6,
 	}
@@ -400,9 +528,13 @@ func IsIPV6(option ...ActionOptionFace) StringPipeAction {
 // IsJSON validates that a string is valid JSON data.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsJSON(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid JSON string", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.json",
+				msg:  "not a valid JSON string",
+			}
 		},
 		validate: is.IsJSON,
 	}
@@ -411,9 +543,13 @@ func IsJSON(option ...ActionOptionFace) StringPipeAction {
 // IsRGB validates that a string represents a valid RGB color.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRGB(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid RGB color", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.rgb",
+				msg:  "not a valid RGB color",
+			}
 		},
 		validate: is.IsRGB,
 	}
@@ -422,9 +558,13 @@ func IsRGB(option ...ActionOptionFace) StringPipeAction {
 // IsULID validates that a string is a valid ULID (Universally Unique Lexicographically Sortable Identifier).
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsULID(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid ULID", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.ulid",
+				msg:  "not a valid ULID",
+			}
 		},
 		validate: is.IsULID,
 	}
@@ -433,9 +573,14 @@ func IsULID(option ...ActionOptionFace) StringPipeAction {
 // IsURL validates that a string is a valid URL.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsURL(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid URL", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.url",
+				msg:    "not a valid URL",
+				schema: JSONSchema{"format": "uri"},
+			}
 		},
 		validate: is.IsURL,
 	}
@@ -444,9 +589,14 @@ func IsURL(option ...ActionOptionFace) StringPipeAction {
 // IsUUID validates that a string is a valid UUID in any version.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsUUID(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid UUID", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.uuid",
+				msg:    "not a valid UUID",
+				schema: JSONSchema{"format": "uuid"},
+			}
 		},
 		validate: is.IsUUID,
 	}
@@ -455,9 +605,13 @@ func IsUUID(option ...ActionOptionFace) StringPipeAction {
 // IsUUIDV1 validates that a string is a valid UUID version 1.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsUUIDV1(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid UUIDv1", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.uuid_v1",
+				msg:  "not a valid UUIDv1",
+			}
 		},
 		validate: is.IsUUIDV1,
 	}
@@ -466,9 +620,13 @@ func IsUUIDV1(option ...ActionOptionFace) StringPipeAction {
 // IsUUIDV3 validates that a string is a valid UUID version 3.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsUUIDV3(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid UUIDv3", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.uuid_v3",
+				msg:  "not a valid UUIDv3",
+			}
 		},
 		validate: is.IsUUIDV3,
 	}
@@ -477,9 +635,13 @@ func IsUUIDV3(option ...ActionOptionFace) StringPipeAction {
 // IsUUIDV4 validates that a string is a valid UUID version 4.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsUUIDV4(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid UUIDv4", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.uuid_v4",
+				msg:  "not a valid UUIDv4",
+			}
 		},
 		validate: is.IsUUIDV4,
 	}
@@ -488,9 +650,13 @@ func IsUUIDV4(option ...ActionOptionFace) StringPipeAction {
 // IsUUIDV5 validates that a string is a valid UUID version 5.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsUUIDV5(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid UUIDv5", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.uuid_v5",
+				msg:  "not a valid UUIDv5",
+			}
 		},
 		validate: is.IsUUIDV5,
 	}
@@ -499,9 +665,13 @@ func IsUUIDV5(option ...ActionOptionFace) StringPipeAction {
 // IsValidPath validates that a string is a valid file system path.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsValidPath(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid path", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.path",
+				msg:  "not a valid path",
+			}
 		},
 		validate: is.IsValidPath,
 	}
@@ -510,9 +680,13 @@ func IsValidPath(option ...ActionOptionFace) StringPipeAction {
 // IsValidPort validates that a string represents a valid port number (0-65535).
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsValidPort(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid port number", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.port",
+				msg:  "not a valid port number",
+			}
 		},
 		validate: is.IsValidPort,
 	}
@@ -521,9 +695,13 @@ func IsValidPort(option ...ActionOptionFace) StringPipeAction {
 // IsXML validates that a string is valid XML data.
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsXML(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid XML string", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.xml",
+				msg:  "not a valid XML string",
+			}
 		},
 		validate: is.IsXML,
 	}
@@ -533,9 +711,13 @@ func IsXML(option ...ActionOptionFace) StringPipeAction {
 // Format: "Mon Jan _2 15:04:05 2006"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsANSIC(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid ANSIC time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.ansic",
+				msg:  "not a valid ANSIC time format",
+			}
 		},
 		validate: is.IsANSIC,
 	}
@@ -545,9 +727,13 @@ func IsANSIC(option ...ActionOptionFace) StringPipeAction {
 // Format: "Mon Jan _2 15:04:05 MST 2006"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsUnixDate(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid Unix date format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.unix_date",
+				msg:  "not a valid Unix date format",
+			}
 		},
 		validate: is.IsUnixDate,
 	}
@@ -557,9 +743,13 @@ func IsUnixDate(option ...ActionOptionFace) StringPipeAction {
 // Format: "Mon Jan 02 15:04:05 -0700 2006"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRubyDate(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid Ruby date format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.ruby_date",
+				msg:  "not a valid Ruby date format",
+			}
 		},
 		validate: is.IsRubyDate,
 	}
@@ -569,9 +759,13 @@ func IsRubyDate(option ...ActionOptionFace) StringPipeAction {
 // Format: "02 Jan 06 15:04 MST"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRFC822(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid RFC822 time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.rfc822",
+				msg:  "not a valid RFC822 time format",
+			}
 		},
 		validate: is.IsRFC822,
 	}
@@ -581,9 +775,13 @@ func IsRFC822(option ...ActionOptionFace) StringPipeAction {
 // Format: "02 Jan 06 15:04 -0700"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRFC822Z(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid RFC822Z time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.rfc822z",
+				msg:  "not a valid RFC822Z time format",
+			}
 		},
 		validate: is.IsRFC822Z,
 	}
@@ -593,9 +791,13 @@ func IsRFC822Z(option ...ActionOptionFace) StringPipeAction {
 // Format: "Monday, 02-Jan-06 15:04:05 MST"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRFC850(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid RFC850 time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.rfc850",
+				msg:  "not a valid RFC850 time format",
+			}
 		},
 		validate: is.IsRFC850,
 	}
@@ -605,9 +807,13 @@ func IsRFC850(option ...ActionOptionFace) StringPipeAction {
 // Format: "Mon, 02 Jan 2006 15:04:05 MST"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRFC1123(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid RFC1123 time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.rfc1123",
+				msg:  "not a valid RFC1123 time format",
+			}
 		},
 		validate: is.IsRFC1123,
 	}
@@ -617,9 +823,13 @@ func IsRFC1123(option ...ActionOptionFace) StringPipeAction {
 // Format: "Mon, 02 Jan 2006 15:04:05 -0700"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRFC1123Z(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid RFC1123Z time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.rfc1123z",
+				msg:  "not a valid RFC1123Z time format",
+			}
 		},
 		validate: is.IsRFC1123Z,
 	}
@@ -629,9 +839,14 @@ func IsRFC1123Z(option ...ActionOptionFace) StringPipeAction {
 // Format: "2006-01-02T15:04:05Z07:00"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRFC3339(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid RFC3339 time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "string.rfc3339",
+				msg:    "not a valid RFC3339 time format",
+				schema: JSONSchema{"format": "date-time"},
+			}
 		},
 		validate: is.IsRFC3339,
 	}
@@ -641,9 +856,13 @@ func IsRFC3339(option ...ActionOptionFace) StringPipeAction {
 // Format: "2006-01-02T15:04:05.999999999Z07:00"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsRFC3339Nano(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid RFC3339Nano time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.rfc3339_nano",
+				msg:  "not a valid RFC3339Nano time format",
+			}
 		},
 		validate: is.IsRFC3339Nano,
 	}
@@ -653,9 +872,13 @@ func IsRFC3339Nano(option ...ActionOptionFace) StringPipeAction {
 // Format: "3:04PM"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsKitchen(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid Kitchen time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.kitchen",
+				msg:  "not a valid Kitchen time format",
+			}
 		},
 		validate: is.IsKitchen,
 	}
@@ -665,9 +888,13 @@ func IsKitchen(option ...ActionOptionFace) StringPipeAction {
 // Format: "Jan _2 15:04:05"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsStamp(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid Stamp time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.stamp",
+				msg:  "not a valid Stamp time format",
+			}
 		},
 		validate: is.IsStamp,
 	}
@@ -677,9 +904,13 @@ func IsStamp(option ...ActionOptionFace) StringPipeAction {
 // Format: "Jan _2 15:04:05.000"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsStampMilli(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid StampMilli time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.stamp_milli",
+				msg:  "not a valid StampMilli time format",
+			}
 		},
 		validate: is.IsStampMilli,
 	}
@@ -689,9 +920,13 @@ func IsStampMilli(option ...ActionOptionFace) StringPipeAction {
 // Format: "Jan _2 15:04:05.000000"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsStampMicro(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid StampMicro time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.stamp_micro",
+				msg:  "not a valid StampMicro time format",
+			}
 		},
 		validate: is.IsStampMicro,
 	}
@@ -701,9 +936,13 @@ func IsStampMicro(option ...ActionOptionFace) StringPipeAction {
 // Format: "Jan _2 15:04:05.000000000"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsStampNano(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid StampNano time format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.stamp_nano",
+				msg:  "not a valid StampNano time format",
+			}
 		},
 		validate: is.IsStampNano,
 	}
@@ -713,9 +952,13 @@ func IsStampNano(option ...ActionOptionFace) StringPipeAction {
 // Format: "2006-01-02 15:04:05"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsDateTime(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid DateTime format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.date_time",
+				msg:  "not a valid DateTime format",
+			}
 		},
 		validate: is.IsDateTime,
 	}
@@ -725,9 +968,13 @@ func IsDateTime(option ...ActionOptionFace) StringPipeAction {
 // Format: "15:04:05"
 // The optional ActionOptions parameter can be used to customize the error message.
 func IsTimeOnly(option ...ActionOptionFace) StringPipeAction {
-	return &stringAction{
-		errorMsg: func(v string) string {
-			return extractMsg("not a valid TimeOnly format", v, option...)
+	return &action[string]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "string.time_only",
+				msg:  "not a valid TimeOnly format",
+			}
 		},
 		validate: is.IsTimeOnly,
 	}
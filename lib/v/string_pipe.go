@@ -1,18 +1,8 @@
 package v
 
-// stringPipeManager manages the validation pipeline for string values.
-type stringPipeManager struct {
-	actions []StringPipeAction
-	value   string
-	key     string
-	error   error
-}
-
 // StringPipeAction defines the interface for string validation actions.
 // Each action can run validation logic on a string value and return an error if validation fails.
-type StringPipeAction interface {
-	Run(v string) error
-}
+type StringPipeAction = Action[string]
 
 // StringPipe creates a new validation pipe for string values.
 // The pipe executes the provided actions in sequence during validation.
@@ -23,37 +13,6 @@ type StringPipeAction interface {
 //	if err := pipe.Validate(); err != nil {
 //	    log.Fatal(err)
 //	}
-func StringPipe(value string, actions ...StringPipeAction) PipeFace {
-	return &stringPipeManager{
-		value:   value,
-		actions: actions,
-		error:   nil,
-	}
-}
-
-// setKey sets the validation key for this pipe.
-// This key is used in error messages to identify which field failed validation.
-func (pipe *stringPipeManager) setKey(k string) {
-	pipe.key = k
-}
-
-// Key returns the validation key associated with this pipe.
-func (pipe *stringPipeManager) Key() string {
-	return pipe.key
-}
-
-// Validate runs all validation actions in sequence.
-// Returns a FieldError if any action fails, otherwise returns nil.
-func (pipe *stringPipeManager) Validate() error {
-
-	// hasError := false
-
-	for _, action := range pipe.actions {
-		if err := action.Run(pipe.value); err != nil {
-			return NewPipeError(pipe.key, err)
-		}
-
-	}
-
-	return nil
+func StringPipe(value string, actions ...StringPipeAction) *Pipe[string] {
+	return NewPipe(value, actions...)
 }
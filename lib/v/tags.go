@@ -0,0 +1,480 @@
+package v
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tagKey is the struct tag holding tag rules, e.g. `valgo:"required,email,max=40"`.
+const tagKey = "valgo"
+
+// TagRule is a single rule of a valgo tag, e.g. max=40.
+type TagRule struct {
+	Name string
+	Arg  string
+}
+
+// tagField builds the pipe of one tagged struct field.
+type tagField struct {
+	index []int
+	key   string
+	build func(field reflect.Value) PipeFace
+}
+
+// tagSchema holds the compiled tag rules of a struct type.
+type tagSchema struct {
+	fields []tagField
+	err    error
+}
+
+// tagSchemas caches the compiled tag rules per struct type.
+var tagSchemas sync.Map
+
+// generatedSchema is implemented by schemas whose Rules method was generated
+// from their valgo tags by cmd/valgen, so the tags aren't compiled again.
+type generatedSchema interface {
+	ValgoGenerated()
+}
+
+// rulesOf returns the rules of s: the pipes compiled from its valgo tags,
+// followed by the pipe set returned by its own [Schema.Rules].
+func rulesOf(s Schema) (PipeSet, error) {
+	rules, err := s.Rules()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.(generatedSchema); ok {
+		return rules, nil
+	}
+
+	pipes, err := tagPipes(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(pipes) == 0 {
+		return rules, nil
+	}
+
+	if rules != nil {
+		pipes = append(pipes, ObjectPipe(rules))
+	}
+	return NewPipesBuilder(pipes...), nil
+}
+
+// tagPipes builds the pipes of the valgo tags of s for its current field values.
+func tagPipes(s Schema) ([]PipeFace, error) {
+	rv := reflect.ValueOf(s)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	compiled := compileTags(rv.Type())
+	if compiled.err != nil {
+		return nil, compiled.err
+	}
+
+	pipes := make([]PipeFace, 0, len(compiled.fields))
+	for _, f := range compiled.fields {
+		pipe := f.build(rv.FieldByIndex(f.index))
+		pipe.setKey(f.key)
+		pipes = append(pipes, pipe)
+	}
+	return pipes, nil
+}
+
+// compileTags compiles the valgo tags of the struct type t once.
+func compileTags(t reflect.Type) *tagSchema {
+	if cached, ok := tagSchemas.Load(t); ok {
+		return cached.(*tagSchema)
+	}
+
+	compiled := &tagSchema{}
+	for _, sf := range reflect.VisibleFields(t) {
+		tag, ok := sf.Tag.Lookup(tagKey)
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+
+		build, err := compileField(sf.Type, ParseTag(tag))
+		if err != nil {
+			compiled.err = fmt.Errorf("valgo: %s.%s: %w", t.Name(), sf.Name, err)
+			break
+		}
+		compiled.fields = append(compiled.fields, tagField{index: sf.Index, key: jsonKey(sf), build: build})
+	}
+
+	cached, _ := tagSchemas.LoadOrStore(t, compiled)
+	return cached.(*tagSchema)
+}
+
+// ParseTag splits a valgo tag into its rules. A pattern rule takes the rest
+// of the tag, so its regular expression may contain commas.
+func ParseTag(tag string) []TagRule {
+	var rules []TagRule
+	for tag != "" {
+		part := tag
+		if strings.HasPrefix(tag, "pattern=") {
+			tag = ""
+		} else if i := strings.IndexByte(tag, ','); i >= 0 {
+			part, tag = tag[:i], tag[i+1:]
+		} else {
+			tag = ""
+		}
+
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			rules = append(rules, TagRule{Name: name, Arg: arg})
+		}
+	}
+	return rules
+}
+
+// jsonKey returns the JSON name of a struct field, the key of its errors.
+func jsonKey(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isTimeType reports whether t is time.Time or a type defined on it,
+// such as type Stamp time.Time.
+func isTimeType(t reflect.Type) bool {
+	return t == timeType || t.Kind() == reflect.Struct && t.ConvertibleTo(timeType)
+}
+
+// compileField returns the pipe builder of a field of type t.
+func compileField(t reflect.Type, rules []TagRule) (func(reflect.Value) PipeFace, error) {
+	if t.Kind() == reflect.Pointer {
+		return compilePointer(t, rules)
+	}
+
+	switch {
+	case isTimeType(t):
+		actions, transforms, err := compileActions(timeTagRules, rules)
+		if err != nil {
+			return nil, err
+		}
+		return func(field reflect.Value) PipeFace {
+			return NewPipe(field.Convert(timeType).Interface().(time.Time), withSetter(actions, transforms, field, func(t time.Time) {
+				field.Set(reflect.ValueOf(t).Convert(field.Type()))
+			})...)
+		}, nil
+	case t.Kind() == reflect.String:
+		actions, transforms, err := compileActions(stringTagRules, rules)
+		if err != nil {
+			return nil, err
+		}
+		return func(field reflect.Value) PipeFace {
+			return NewPipe(field.String(), withSetter(actions, transforms, field, field.SetString)...)
+		}, nil
+	case isIntKind(t.Kind()):
+		actions, transforms, err := compileActions(intTagRules, rules)
+		if err != nil {
+			return nil, err
+		}
+		return func(field reflect.Value) PipeFace {
+			return NewPipe(int(field.Int()), withSetter(actions, transforms, field, func(n int) {
+				field.SetInt(int64(n))
+			})...)
+		}, nil
+	case isUintKind(t.Kind()):
+		actions, transforms, err := compileActions(intTagRules, rules)
+		if err != nil {
+			return nil, err
+		}
+		return func(field reflect.Value) PipeFace {
+			if n := field.Uint(); n > math.MaxInt {
+				return NewPipe(n, intRange())
+			}
+			return NewPipe(int(field.Uint()), withSetter(actions, transforms, field, func(n int) {
+				field.SetUint(uint64(n))
+			})...)
+		}, nil
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		actions, transforms, err := compileActions(floatTagRules, rules)
+		if err != nil {
+			return nil, err
+		}
+		return func(field reflect.Value) PipeFace {
+			return NewPipe(field.Float(), withSetter(actions, transforms, field, field.SetFloat)...)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// intRange fails an unsigned field whose value doesn't fit in the int the
+// integer rules run on, rather than letting it wrap around.
+func intRange() Action[uint64] {
+	return &action[uint64]{
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "int.range",
+				msg:    fmt.Sprintf("must be at most %d", math.MaxInt),
+				params: map[string]any{"max": math.MaxInt},
+			}
+		},
+		validate: func(n uint64) bool {
+			return n <= math.MaxInt
+		},
+	}
+}
+
+// compilePointer returns the pipe builder of a *string, *int, *float64 or
+// *time.Time field. The required rule turns it into a [Required] pipe,
+// otherwise it is [Optional]. Transform rules are rejected: a nil pointer has
+// no value to rewrite.
+func compilePointer(t reflect.Type, rules []TagRule) (func(reflect.Value) PipeFace, error) {
+	required := false
+	rest := rules[:0:0]
+	for _, rule := range rules {
+		if rule.Name == "required" {
+			required = true
+			continue
+		}
+		rest = append(rest, rule)
+	}
+
+	switch t.Elem() {
+	case reflect.TypeOf(""):
+		return compilePointerOf(stringTagRules, rest, required)
+	case reflect.TypeOf(0):
+		return compilePointerOf(intTagRules, rest, required)
+	case reflect.TypeOf(0.0):
+		return compilePointerOf(floatTagRules, rest, required)
+	case timeType:
+		return compilePointerOf(timeTagRules, rest, required)
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+func compilePointerOf[T any](table map[string]func(arg string) (Action[T], error), rules []TagRule, required bool) (func(reflect.Value) PipeFace, error) {
+	actions, transforms, err := compileActions(table, rules)
+	if err != nil {
+		return nil, err
+	}
+	if transforms {
+		return nil, errors.New("transform rules don't apply to pointer fields")
+	}
+	return func(field reflect.Value) PipeFace {
+		if required {
+			return Required(field.Interface().(*T), actions...)
+		}
+		return Optional(field.Interface().(*T), actions...)
+	}, nil
+}
+
+// TagRuleNames returns the sorted names of the rules a valgo tag accepts on
+// fields of type t, or nil if t can't be tagged. Pointer fields accept the
+// rules of their element type.
+func TagRuleNames(t reflect.Type) []string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case isTimeType(t):
+		return slices.Sorted(maps.Keys(timeTagRules))
+	case t.Kind() == reflect.String:
+		return slices.Sorted(maps.Keys(stringTagRules))
+	case isIntKind(t.Kind()) || isUintKind(t.Kind()):
+		return slices.Sorted(maps.Keys(intTagRules))
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return slices.Sorted(maps.Keys(floatTagRules))
+	}
+	return nil
+}
+
+// compileActions looks up every rule in table and reports whether any of them is a transform.
+func compileActions[T any](table map[string]func(arg string) (Action[T], error), rules []TagRule) (actions []Action[T], transforms bool, err error) {
+	for _, rule := range rules {
+		build, ok := table[rule.Name]
+		if !ok {
+			return nil, false, fmt.Errorf("unknown rule %q", rule.Name)
+		}
+		action, err := build(rule.Arg)
+		if err != nil {
+			return nil, false, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if _, ok := action.(transformer[T]); ok {
+			transforms = true
+		}
+		actions = append(actions, action)
+	}
+	return actions, transforms, nil
+}
+
+// withSetter appends an action that writes the transformed value back into
+// field, so tag transforms such as trim behave like [Pipe.Bind].
+func withSetter[T any](actions []Action[T], transforms bool, field reflect.Value, set func(T)) []Action[T] {
+	if !transforms || !field.CanSet() {
+		return actions
+	}
+	return append(actions[:len(actions):len(actions)], ActionFunc[T](func(value T) error {
+		set(value)
+		return nil
+	}))
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uint64
+}
+
+// tagNoArg adapts an action constructor without arguments to a tag rule.
+func tagNoArg[T any](build func(option ...ActionOptionFace) Action[T]) func(string) (Action[T], error) {
+	return func(arg string) (Action[T], error) {
+		if arg != "" {
+			return nil, errors.New("takes no argument")
+		}
+		return build(), nil
+	}
+}
+
+// tagArg adapts an action constructor taking one parsed argument to a tag rule.
+func tagArg[A, T any](parse func(string) (A, error), build func(arg A, option ...ActionOptionFace) Action[T]) func(string) (Action[T], error) {
+	return func(arg string) (Action[T], error) {
+		value, err := parse(arg)
+		if err != nil {
+			return nil, err
+		}
+		return build(value), nil
+	}
+}
+
+// tagTransform adapts a transform constructor to a tag rule.
+func tagTransform[T any](build func() Action[T]) func(string) (Action[T], error) {
+	return func(arg string) (Action[T], error) {
+		if arg != "" {
+			return nil, errors.New("takes no argument")
+		}
+		return build(), nil
+	}
+}
+
+func parseInt(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an integer", arg)
+	}
+	return n, nil
+}
+
+func parseFloat(arg string) (float64, error) {
+	n, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", arg)
+	}
+	return n, nil
+}
+
+func parseString(arg string) (string, error) {
+	if arg == "" {
+		return "", errors.New("needs an argument")
+	}
+	return arg, nil
+}
+
+func parseRegexp(arg string) (string, error) {
+	if _, err := regexp.Compile(arg); err != nil {
+		return "", err
+	}
+	return arg, nil
+}
+
+func parseFields(arg string) ([]string, error) {
+	values := strings.Fields(arg)
+	if len(values) == 0 {
+		return nil, errors.New("needs at least one value")
+	}
+	return values, nil
+}
+
+func parseRFC3339(arg string) (time.Time, error) {
+	return time.Parse(time.RFC3339, arg)
+}
+
+// stringTagRules maps the rule names of string fields to their actions.
+var stringTagRules = map[string]func(arg string) (StringPipeAction, error){
+	"required":   tagNoArg(NotEmpty),
+	"min":        tagArg(parseInt, MinLength),
+	"max":        tagArg(parseInt, MaxLength),
+	"email":      tagNoArg(IsEmail),
+	"url":        tagNoArg(IsURL),
+	"uuid":       tagNoArg(IsUUID),
+	"ulid":       tagNoArg(IsULID),
+	"alpha":      tagNoArg(IsAlpha),
+	"alphanum":   tagNoArg(IsAlphaNumeric),
+	"ascii":      tagNoArg(IsAscii),
+	"decimal":    tagNoArg(IsDecimal),
+	"ipv4":       tagNoArg(IsIPV4),
+	"ipv6":       tagNoArg(IsIPV6),
+	"json":       tagNoArg(IsJSON),
+	"base64":     tagNoArg(IsBase64),
+	"hexcolor":   tagNoArg(IsHexColor),
+	"creditcard": tagNoArg(IsCreditCard),
+	"prefix":     tagArg(parseString, HasPrefix),
+	"suffix":     tagArg(parseString, HasSuffix),
+	"contains":   tagArg(parseString, Contains),
+	"oneof":      tagArg(parseFields, Enum),
+	"pattern":    tagArg(parseRegexp, Pattern),
+	"trim":       tagTransform(Trim),
+	"lower":      tagTransform(ToLower),
+	"upper":      tagTransform(ToUpper),
+}
+
+// intTagRules maps the rule names of integer fields to their actions.
+var intTagRules = map[string]func(arg string) (IntPipeAction, error){
+	"required": tagNoArg(NonZero),
+	"min":      tagArg(parseInt, Min),
+	"max":      tagArg(parseInt, Max),
+	"gt":       tagArg(parseInt, Gt),
+	"gte":      tagArg(parseInt, Gte),
+	"lt":       tagArg(parseInt, Lt),
+	"lte":      tagArg(parseInt, Lte),
+	"positive": tagNoArg(IsPositive),
+	"negative": tagNoArg(IsNegative),
+}
+
+// floatTagRules maps the rule names of float fields to their actions.
+var floatTagRules = map[string]func(arg string) (FloatPipeAction, error){
+	"required": tagNoArg(NonZeroFloat),
+	"min":      tagArg(parseFloat, MinFloat),
+	"max":      tagArg(parseFloat, MaxFloat),
+	"gt":       tagArg(parseFloat, GtFloat),
+	"gte":      tagArg(parseFloat, GteFloat),
+	"lt":       tagArg(parseFloat, LtFloat),
+	"lte":      tagArg(parseFloat, LteFloat),
+	"positive": tagNoArg(IsPositiveFloat),
+	"negative": tagNoArg(IsNegativeFloat),
+}
+
+// timeTagRules maps the rule names of time.Time fields to their actions.
+var timeTagRules = map[string]func(arg string) (TimePipeAction, error){
+	"required": tagNoArg(NotEmptyDate),
+	"before":   tagArg(parseRFC3339, Before),
+	"after":    tagArg(parseRFC3339, After),
+	"past":     tagNoArg(BeforeNow),
+	"future":   tagNoArg(AfterNow),
+	"weekday":  tagNoArg(IsWeekday),
+}
@@ -0,0 +1,179 @@
+package v
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// templateLookup returns the value of a placeholder name, if known.
+type templateLookup func(name string) (any, bool)
+
+// renderTemplate fills the placeholders of tmpl known to lookup.
+func renderTemplate(tmpl string, lookup templateLookup) string {
+	if !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(tmpl[:start])
+		if text, ok := renderPlaceholder(tmpl[start+1:end], lookup); ok {
+			b.WriteString(text)
+		} else {
+			b.WriteString(tmpl[start : end+1])
+		}
+		tmpl = tmpl[end+1:]
+	}
+	b.WriteString(tmpl)
+	return b.String()
+}
+
+func renderPlaceholder(placeholder string, lookup templateLookup) (string, bool) {
+	name, filters, _ := strings.Cut(placeholder, "|")
+	value, ok := lookup(strings.TrimSpace(name))
+	if !ok {
+		return "", false
+	}
+	if filters == "" {
+		return formatValue(value), true
+	}
+
+	for _, filter := range strings.Split(filters, "|") {
+		if value, ok = applyFilter(strings.TrimSpace(filter), value); !ok {
+			return "", false
+		}
+	}
+	return formatValue(value), true
+}
+
+// applyFilter applies one filter, e.g. truncate:20, to value.
+func applyFilter(filter string, value any) (any, bool) {
+	name, arg, hasArg := strings.Cut(filter, ":")
+	switch name {
+	case "date":
+		t, ok := value.(time.Time)
+		if !ok || !hasArg {
+			return value, ok && hasArg
+		}
+		return t.Format(arg), true
+	case "truncate":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		s := formatValue(value)
+		if utf8.RuneCountInString(s) <= n {
+			return s, true
+		}
+		return string([]rune(s)[:n]) + "…", true
+	case "mask":
+		if !hasArg {
+			return "********", true
+		}
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		runes := []rune(formatValue(value))
+		if n >= len(runes) {
+			return strings.Repeat("*", len(runes)), true
+		}
+		return strings.Repeat("*", len(runes)-n) + string(runes[len(runes)-n:]), true
+	}
+	return nil, false
+}
+
+// valueLookup resolves {VALUE} and {LEN}.
+func valueLookup(value any) templateLookup {
+	return func(name string) (any, bool) {
+		switch name {
+		case "VALUE":
+			return value, true
+		case "LEN":
+			return valueLen(value)
+		}
+		return nil, false
+	}
+}
+
+// paramLookup resolves {PARAM.name}.
+func paramLookup(params map[string]any) templateLookup {
+	return func(name string) (any, bool) {
+		param, ok := strings.CutPrefix(name, "PARAM.")
+		if !ok {
+			return nil, false
+		}
+		value, ok := params[param]
+		return value, ok
+	}
+}
+
+// keyLookup resolves {KEY}.
+func keyLookup(key string) templateLookup {
+	return func(name string) (any, bool) {
+		return key, name == "KEY"
+	}
+}
+
+// errorLookup resolves the placeholders of the message of a rule that failed
+// on value: {VALUE}, {LEN} and {PARAM.name}, and {KEY} once the field is known.
+func errorLookup(value any, params map[string]any, key ...string) templateLookup {
+	lookups := []templateLookup{valueLookup(value), paramLookup(params)}
+	if len(key) > 0 {
+		lookups = append(lookups, keyLookup(key[0]))
+	}
+	return func(name string) (any, bool) {
+		for _, lookup := range lookups {
+			if value, ok := lookup(name); ok {
+				return value, true
+			}
+		}
+		return nil, false
+	}
+}
+
+// valueLen returns the length of strings, slices, arrays and maps.
+func valueLen(value any) (int, bool) {
+	if s, ok := value.(string); ok {
+		return utf8.RuneCountInString(s), true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len(), true
+	}
+	return 0, false
+}
+
+// formatValue renders a value or param in a message.
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case []string:
+		return strings.Join(val, ", ")
+	case time.Time:
+		return val.Format(time.RFC3339)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case fmt.Stringer:
+		return val.String()
+	}
+	return fmt.Sprint(v)
+}
@@ -5,21 +5,6 @@ import (
 	"time"
 )
 
-// timeAction implements TimePipeAction for time.Time validation.
-type timeAction struct {
-	errorMsg func(v time.Time) string
-	validate func(v time.Time) bool
-}
-
-// Run executes the validation function on the given time.Time value.
-// Returns an error if validation fails.
-func (action *timeAction) Run(value time.Time) error {
-	if !action.validate(value) {
-		return fmt.Errorf("%s", action.errorMsg(value))
-	}
-	return nil
-}
-
 // CustomTime creates a custom time validator using the provided validation function.
 // The optional ActionOptions parameter can be used to customize the error message.
 //
@@ -27,9 +12,13 @@ func (action *timeAction) Run(value time.Time) error {
 //
 //	CustomTime(func(v time.Time) bool { return v.Hour() >= 9 && v.Hour() < 17 })
 func CustomTime(fn func(value time.Time) bool, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("invalid time", v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "time.custom",
+				msg:  "invalid time",
+			}
 		},
 		validate: fn,
 	}
@@ -42,9 +31,14 @@ func CustomTime(fn func(value time.Time) bool, option ...ActionOptionFace) TimeP
 //
 //	Before(time.Now()) // validates v < now
 func Before(t time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be before "+t.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.before",
+				msg:    "time must be before " + t.String(),
+				params: map[string]any{"time": t},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.Before(t)
@@ -59,9 +53,14 @@ func Before(t time.Time, option ...ActionOptionFace) TimePipeAction {
 //
 //	After(time.Now()) // validates v > now
 func After(t time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be after "+t.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.after",
+				msg:    "time must be after " + t.String(),
+				params: map[string]any{"time": t},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.After(t)
@@ -76,9 +75,14 @@ func After(t time.Time, option ...ActionOptionFace) TimePipeAction {
 //
 //	Between(startDate, endDate) // validates startDate < v < endDate
 func Between(start time.Time, end time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be between "+start.String()+" and "+end.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.between",
+				msg:    "time must be between " + start.String() + " and " + end.String(),
+				params: map[string]any{"start": start, "end": end},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.After(start) && v.Before(end)
@@ -89,9 +93,13 @@ func Between(start time.Time, end time.Time, option ...ActionOptionFace) TimePip
 // BeforeNow validates that a time.Time value is in the past (before the current time).
 // The optional ActionOptions parameter can be used to customize the error message.
 func BeforeNow(option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be in the past", v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "time.past",
+				msg:  "time must be in the past",
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.Before(time.Now())
@@ -102,9 +110,13 @@ func BeforeNow(option ...ActionOptionFace) TimePipeAction {
 // AfterNow validates that a time.Time value is in the future (after the current time).
 // The optional ActionOptions parameter can be used to customize the error message.
 func AfterNow(option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be in the future", v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "time.future",
+				msg:  "time must be in the future",
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.After(time.Now())
@@ -115,9 +127,14 @@ func AfterNow(option ...ActionOptionFace) TimePipeAction {
 // NotZero validates that a time.Time value is not the zero value.
 // The optional ActionOptions parameter can be used to customize the error message.
 func NotEmptyDate(option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time cannot be zero value", v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:     "time.not_zero",
+				msg:      "time cannot be zero value",
+				required: true,
+			}
 		},
 		validate: func(v time.Time) bool {
 			return !v.IsZero()
@@ -129,9 +146,14 @@ func NotEmptyDate(option ...ActionOptionFace) TimePipeAction {
 // The comparison is done using year, month, and day only, ignoring the time component.
 // The optional ActionOptions parameter can be used to customize the error message.
 func SameDay(t time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be on the same day as "+t.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.same_day",
+				msg:    "time must be on the same day as " + t.String(),
+				params: map[string]any{"time": t},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.Year() == t.Year() && v.Month() == t.Month() && v.Day() == t.Day()
@@ -143,9 +165,14 @@ func SameDay(t time.Time, option ...ActionOptionFace) TimePipeAction {
 // The comparison is done using year and month only, ignoring the day and time components.
 // The optional ActionOptions parameter can be used to customize the error message.
 func SameMonth(t time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be in the same month as "+t.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.same_month",
+				msg:    "time must be in the same month as " + t.String(),
+				params: map[string]any{"time": t},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.Year() == t.Year() && v.Month() == t.Month()
@@ -157,9 +184,14 @@ func SameMonth(t time.Time, option ...ActionOptionFace) TimePipeAction {
 // The comparison is done using year only, ignoring all other components.
 // The optional ActionOptions parameter can be used to customize the error message.
 func SameYear(t time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be in the same year as "+t.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.same_year",
+				msg:    "time must be in the same year as " + t.String(),
+				params: map[string]any{"time": t},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.Year() == t.Year()
@@ -172,9 +204,14 @@ func SameYear(t time.Time, option ...ActionOptionFace) TimePipeAction {
 // Edge cases: zero values are considered valid if they match the condition.
 // The optional ActionOptions parameter can be used to customize the error message.
 func MinDate(minDate time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be on or after "+minDate.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.min",
+				msg:    "time must be on or after " + minDate.String(),
+				params: map[string]any{"min": minDate},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.After(minDate) || v.Equal(minDate)
@@ -187,9 +224,14 @@ func MinDate(minDate time.Time, option ...ActionOptionFace) TimePipeAction {
 // Edge cases: zero values are considered valid if they match the condition.
 // The optional ActionOptions parameter can be used to customize the error message.
 func MaxDate(maxDate time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be on or before "+maxDate.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.max",
+				msg:    "time must be on or before " + maxDate.String(),
+				params: map[string]any{"max": maxDate},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.Before(maxDate) || v.Equal(maxDate)
@@ -203,9 +245,14 @@ func MaxDate(maxDate time.Time, option ...ActionOptionFace) TimePipeAction {
 // Edge case consideration: This comparison includes nanosecond precision, so times parsed from
 // different sources may not be equal due to nanosecond differences.
 func EqualTime(t time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must equal "+t.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.equal",
+				msg:    "time must equal " + t.String(),
+				params: map[string]any{"time": t},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return v.Equal(t)
@@ -218,9 +265,14 @@ func EqualTime(t time.Time, option ...ActionOptionFace) TimePipeAction {
 //
 // Edge case consideration: This comparison includes nanosecond precision.
 func NotEqual(t time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must not equal "+t.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.not_equal",
+				msg:    "time must not equal " + t.String(),
+				params: map[string]any{"time": t},
+			}
 		},
 		validate: func(v time.Time) bool {
 			return !v.Equal(t)
@@ -243,9 +295,14 @@ func OldOfDays(days int, option ...ActionOptionFace) TimePipeAction {
 	if days < 0 {
 		days = 0
 	}
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg(fmt.Sprintf("time must be at least %d days old", days), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.old_of_days",
+				msg:    fmt.Sprintf("time must be at least %d days old", days),
+				params: map[string]any{"days": days},
+			}
 		},
 		validate: func(v time.Time) bool {
 			cutoff := time.Now().AddDate(0, 0, -days)
@@ -269,9 +326,14 @@ func OldOf(duration time.Duration, option ...ActionOptionFace) TimePipeAction {
 	if duration < 0 {
 		duration = 0
 	}
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg(fmt.Sprintf("time must be at least %v old", duration), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.old_of",
+				msg:    fmt.Sprintf("time must be at least %v old", duration),
+				params: map[string]any{"duration": duration.String()},
+			}
 		},
 		validate: func(v time.Time) bool {
 			cutoff := time.Now().Add(-duration)
@@ -295,9 +357,14 @@ func NewOf(days int, option ...ActionOptionFace) TimePipeAction {
 	if days < 0 {
 		days = 0
 	}
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg(fmt.Sprintf("time must be at least %d days in the future", days), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.new_of",
+				msg:    fmt.Sprintf("time must be at least %d days in the future", days),
+				params: map[string]any{"days": days},
+			}
 		},
 		validate: func(v time.Time) bool {
 			cutoff := time.Now().AddDate(0, 0, days)
@@ -315,9 +382,14 @@ func NewOf(days int, option ...ActionOptionFace) TimePipeAction {
 // - Year boundaries: ISO week can span across calendar year boundaries
 // - First/last week: handled correctly per ISO 8601
 func SameWeek(t time.Time, option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must be in the same week as "+t.String(), v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code:   "time.same_week",
+				msg:    "time must be in the same week as " + t.String(),
+				params: map[string]any{"time": t},
+			}
 		},
 		validate: func(v time.Time) bool {
 			vYear, vWeek := v.ISOWeek()
@@ -333,9 +405,13 @@ func SameWeek(t time.Time, option ...ActionOptionFace) TimePipeAction {
 // Edge cases:
 // - Timezone is preserved: validation is done in the time's local location
 func IsWeekday(option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time must fall on a weekday (Monday-Friday)", v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "time.weekday",
+				msg:  "time must fall on a weekday (Monday-Friday)",
+			}
 		},
 		validate: func(v time.Time) bool {
 			day := v.Weekday()
@@ -353,9 +429,13 @@ func IsWeekday(option ...ActionOptionFace) TimePipeAction {
 // - Zero offset (UTC) is valid
 // - Times without location info are considered UTC and valid
 func IsTimezone(option ...ActionOptionFace) TimePipeAction {
-	return &timeAction{
-		errorMsg: func(v time.Time) string {
-			return extractMsg("time has invalid timezone offset", v, option...)
+	return &action[time.Time]{
+		options: option,
+		describe: func() actionInfo {
+			return actionInfo{
+				code: "time.timezone",
+				msg:  "time has invalid timezone offset",
+			}
 		},
 		validate: func(v time.Time) bool {
 			_, offset := v.Zone()
@@ -0,0 +1,221 @@
+package v
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// transformer is implemented by actions that rewrite the value flowing through a pipe.
+type transformer[T any] interface {
+	transform(v T) T
+}
+
+// TransformFunc adapts a plain function to an action that rewrites the value.
+// The actions after it in the pipe see the rewritten value, and [Pipe.Value]
+// returns the value after all transforms.
+//
+// Example:
+//
+//	slug := v.TransformFunc[string](func(s string) string {
+//	    return strings.ReplaceAll(strings.ToLower(s), " ", "-")
+//	})
+//	v.StringPipe(p.Slug, slug, v.MaxLength(64))
+type TransformFunc[T any] func(v T) T
+
+// Run never fails; a transform only rewrites the value.
+func (f TransformFunc[T]) Run(T) error {
+	return nil
+}
+
+func (f TransformFunc[T]) transform(value T) T {
+	return f(value)
+}
+
+// Trim removes leading and trailing white space.
+func Trim() StringPipeAction {
+	return TransformFunc[string](strings.TrimSpace)
+}
+
+// ToLower maps the string to lower case.
+func ToLower() StringPipeAction {
+	return TransformFunc[string](strings.ToLower)
+}
+
+// ToUpper maps the string to upper case.
+func ToUpper() StringPipeAction {
+	return TransformFunc[string](strings.ToUpper)
+}
+
+// NormalizeSpace trims the string and collapses every run of white space into a single space.
+func NormalizeSpace() StringPipeAction {
+	return TransformFunc[string](func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	})
+}
+
+// NormalizeNFC converts the string to Unicode Normalization Form C, so equal
+// looking input compares equal, e.g. "e\u0301" becomes "\u00e9" and "\u212b"
+// becomes "\u00c5". Runes are canonically decomposed, combining marks are put
+// in canonical order and the result is composed again.
+func NormalizeNFC() StringPipeAction {
+	return TransformFunc[string](nfc)
+}
+
+// StripTags removes HTML tags, leaving their text content.
+func StripTags() StringPipeAction {
+	return TransformFunc[string](func(s string) string {
+		return tagRegex.ReplaceAllString(s, "")
+	})
+}
+
+var tagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Truncate shortens the string to at most max characters.
+// A negative max is treated as 0 and empties the string.
+func Truncate(max int) StringPipeAction {
+	if max < 0 {
+		max = 0
+	}
+	return TransformFunc[string](func(s string) string {
+		if utf8.RuneCountInString(s) <= max {
+			return s
+		}
+		return string([]rune(s)[:max])
+	})
+}
+
+// Clamp limits the number to the range [min, max].
+func Clamp(min, max int) IntPipeAction {
+	return TransformFunc[int](func(n int) int {
+		return minMax(n, min, max)
+	})
+}
+
+// ClampFloat limits the number to the range [min, max].
+func ClampFloat(min, max float64) FloatPipeAction {
+	return TransformFunc[float64](func(n float64) float64 {
+		return minMax(n, min, max)
+	})
+}
+
+func minMax[T int | float64](n, lo, hi T) T {
+	return max(lo, min(n, hi))
+}
+
+// Round rounds the number half away from zero to the given number of decimal places.
+func Round(places int) FloatPipeAction {
+	return TransformFunc[float64](func(n float64) float64 {
+		scale := math.Pow(10, float64(places))
+		return math.Round(n*scale) / scale
+	})
+}
+
+// nfc returns s in Normalization Form C as defined in Unicode Standard Annex #15.
+func nfc(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		runes = decomposeNFD(runes, r)
+	}
+	reorderMarks(runes)
+	return string(composeNFC(runes))
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// decomposeNFD appends the full canonical decomposition of r to dst.
+func decomposeNFD(dst []rune, r rune) []rune {
+	if r >= hangulSBase && r < hangulSBase+hangulSCount {
+		s := r - hangulSBase
+		dst = append(dst, hangulLBase+s/hangulNCount, hangulVBase+(s%hangulNCount)/hangulTCount)
+		if t := s % hangulTCount; t != 0 {
+			dst = append(dst, hangulTBase+t)
+		}
+		return dst
+	}
+	if d, ok := nfcDecompositions[r]; ok {
+		return append(dst, d...)
+	}
+	return append(dst, r)
+}
+
+// reorderMarks sorts every run of combining marks by combining class,
+// keeping marks of the same class in their order.
+func reorderMarks(runes []rune) {
+	for i := 1; i < len(runes); i++ {
+		class := nfcCombiningClass[runes[i]]
+		if class == 0 {
+			continue
+		}
+		for j := i; j > 0 && nfcCombiningClass[runes[j-1]] > class; j-- {
+			runes[j], runes[j-1] = runes[j-1], runes[j]
+		}
+	}
+}
+
+// composeNFC composes each starter with the characters after it that are not
+// blocked from it by a starter or a mark of the same or a higher combining class.
+// It composes in place and returns the shortened slice.
+func composeNFC(runes []rune) []rune {
+	out := runes[:0]
+	starter := -1
+	var lastClass uint8
+
+	for _, r := range runes {
+		class := nfcCombiningClass[r]
+		if starter >= 0 {
+			adjacent := starter == len(out)-1
+			if adjacent || (lastClass != 0 && lastClass < class) {
+				if composed, ok := composePair(out[starter], r); ok {
+					out[starter] = composed
+					continue
+				}
+			}
+		}
+		if class == 0 {
+			starter = len(out)
+		}
+		lastClass = class
+		out = append(out, r)
+	}
+	return out
+}
+
+// Hangul syllable composition constants from the Unicode standard, section 3.12.
+const (
+	hangulSBase  = 0xAC00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulSCount = hangulLCount * hangulNCount
+)
+
+func composePair(a, b rune) (rune, bool) {
+	// leading consonant + vowel
+	if a >= hangulLBase && a < hangulLBase+hangulLCount && b >= hangulVBase && b < hangulVBase+hangulVCount {
+		return hangulSBase + ((a-hangulLBase)*hangulVCount+(b-hangulVBase))*hangulTCount, true
+	}
+	// LV syllable + trailing consonant
+	if a >= hangulSBase && a < hangulSBase+hangulSCount && (a-hangulSBase)%hangulTCount == 0 &&
+		b > hangulTBase && b < hangulTBase+hangulTCount {
+		return a + (b - hangulTBase), true
+	}
+	composed, ok := nfcPairs[[2]rune{a, b}]
+	return composed, ok
+}
@@ -0,0 +1,121 @@
+package v
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownVariant is the error reported for a discriminator value that has
+// no registered schema. Use errors.Is(err, v.ErrUnknownVariant) to detect it.
+var ErrUnknownVariant = errors.New("unknown variant")
+
+// Union parses tagged JSON payloads such as {"type":"card", ...} and {"type":"bank", ...}.
+// It reads the discriminator field, picks the schema registered for its value,
+// decodes into it and validates it with its Rules.
+//
+// Example:
+//
+//	payments := v.NewUnion("type").
+//	    Register("card", func() v.Schema { return &CardPayment{} }).
+//	    Register("bank", func() v.Schema { return &BankPayment{} })
+//
+//	payment, err := payments.ParseFull(r.Body)
+//	switch p := payment.(type) {
+//	case *CardPayment:
+//	case *BankPayment:
+//	}
+type Union struct {
+	discriminator string
+	variants      map[string]func() Schema
+}
+
+// NewUnion creates a union that picks the schema from the discriminator field.
+func NewUnion(discriminator string) *Union {
+	return &Union{
+		discriminator: discriminator,
+		variants:      make(map[string]func() Schema),
+	}
+}
+
+// Register adds the schema for a discriminator value.
+// newSchema must return a new pointer on every call.
+func (u *Union) Register(value string, newSchema func() Schema) *Union {
+	u.variants[value] = newSchema
+	return u
+}
+
+// Parse reads a payload from [io.Reader], decodes it into the registered schema
+// and validates it, returning the first error.
+//
+// The returned schema is nil when the payload is not valid JSON or the
+// discriminator is missing or unknown. All errors are a [ParseError].
+func (u *Union) Parse(reader io.Reader) (Schema, error) {
+	return u.parseReader(reader, parseMode{})
+}
+
+// ParseFull is like [Union.Parse] but returns all validation errors.
+func (u *Union) ParseFull(reader io.Reader) (Schema, error) {
+	return u.parseReader(reader, parseMode{full: true})
+}
+
+// ParseBytes is like [Union.Parse] for a payload in []byte.
+func (u *Union) ParseBytes(data []byte) (Schema, error) {
+	return u.parse(data, parseMode{})
+}
+
+// ParseBytesFull is like [Union.ParseFull] for a payload in []byte.
+func (u *Union) ParseBytesFull(data []byte) (Schema, error) {
+	return u.parse(data, parseMode{full: true})
+}
+
+func (u *Union) parseReader(reader io.Reader, mode parseMode) (Schema, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, &ParseError{ParseError: err}
+	}
+	return u.parse(data, mode)
+}
+
+func (u *Union) parse(data []byte, mode parseMode) (Schema, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, &ParseError{ParseError: err}
+	}
+
+	newSchema, err := u.variant(fields[u.discriminator])
+	if err != nil {
+		pipeErr := NewPipeError(u.discriminator, err)
+		if mode.full {
+			return nil, &ParseError{ValidationError: ValidationErrors{pipeErr}}
+		}
+		return nil, &ParseError{ValidationError: pipeErr}
+	}
+
+	to := newSchema()
+	return to, parseWithDecoder(bytesDecoder(data), to, mode)
+}
+
+// variant returns the schema constructor for the raw discriminator value.
+// A missing or null discriminator is required.
+func (u *Union) variant(raw json.RawMessage) (func() Schema, error) {
+	if raw == nil || string(raw) == "null" {
+		return nil, requiredError()
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, errors.New("must be a string")
+	}
+
+	newSchema, ok := u.variants[value]
+	if !ok {
+		known := slices.Sorted(maps.Keys(u.variants))
+		return nil, fmt.Errorf("%w %q, expected one of: %s", ErrUnknownVariant, value, strings.Join(known, ", "))
+	}
+	return newSchema, nil
+}
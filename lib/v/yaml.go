@@ -0,0 +1,135 @@
+package v
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// writeYAML writes a decoded JSON value as block style YAML. It is the minimal
+// writer behind [OpenAPI.YAML]: maps, lists and the JSON scalars, nothing more.
+func writeYAML(b *bytes.Buffer, value any, indent int) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			writeYAMLMap(b, v, indent, false)
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			writeYAMLList(b, v, indent)
+			return
+		}
+	}
+	b.WriteString(yamlScalar(value))
+	b.WriteByte('\n')
+}
+
+// writeYAMLMap writes the entries of m in key order. With inline set the first
+// entry continues the current line, after a list item dash.
+func writeYAMLMap(b *bytes.Buffer, m map[string]any, indent int, inline bool) {
+	keys := slices.Sorted(func(yield func(string) bool) {
+		for k := range m {
+			if !yield(k) {
+				return
+			}
+		}
+	})
+
+	for i, k := range keys {
+		if i > 0 || !inline {
+			b.WriteString(strings.Repeat(" ", indent))
+		}
+		b.WriteString(yamlString(k))
+		b.WriteByte(':')
+		writeYAMLChild(b, m[k], indent+2)
+	}
+}
+
+func writeYAMLList(b *bytes.Buffer, list []any, indent int) {
+	for _, item := range list {
+		b.WriteString(strings.Repeat(" ", indent))
+		b.WriteByte('-')
+		if m, ok := item.(map[string]any); ok && len(m) > 0 {
+			b.WriteByte(' ')
+			writeYAMLMap(b, m, indent+2, true)
+			continue
+		}
+		writeYAMLChild(b, item, indent+2)
+	}
+}
+
+// writeYAMLChild writes the value of a map entry or list item after its key or dash.
+func writeYAMLChild(b *bytes.Buffer, value any, indent int) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			b.WriteByte('\n')
+			writeYAMLMap(b, v, indent, false)
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			b.WriteByte('\n')
+			writeYAMLList(b, v, indent)
+			return
+		}
+	}
+	b.WriteByte(' ')
+	b.WriteString(yamlScalar(value))
+	b.WriteByte('\n')
+}
+
+func yamlScalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		return yamlString(v)
+	case map[string]any:
+		return "{}"
+	case []any:
+		return "[]"
+	}
+	return "null"
+}
+
+// yamlReserved are the plain scalars YAML parsers may read as something other than a string.
+var yamlReserved = []string{"true", "false", "null", "yes", "no", "on", "off", "y", "n", "~"}
+
+// yamlString returns s as a plain scalar when that is unambiguous,
+// otherwise double quoted. JSON escapes are valid in double quoted YAML.
+func yamlString(s string) string {
+	if isPlainYAML(s) {
+		return s
+	}
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func isPlainYAML(s string) bool {
+	if s == "" || strings.HasSuffix(s, " ") || slices.Contains(yamlReserved, strings.ToLower(s)) {
+		return false
+	}
+	for i, r := range s {
+		letter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if i == 0 && !letter {
+			return false
+		}
+		if !letter && !(r >= '0' && r <= '9') && !strings.ContainsRune("_-./ ,()", r) {
+			return false
+		}
+	}
+	return true
+}
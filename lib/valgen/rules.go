@@ -0,0 +1,199 @@
+package valgen
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+// kind is the pipe a field is validated with.
+type kind uint8
+
+const (
+	kindString kind = iota
+	kindInt
+	kindFloat
+	kindTime
+)
+
+func (k kind) String() string {
+	return [...]string{"string", "int", "float64", "time.Time"}[k]
+}
+
+// goType returns the Go type of the pipe value.
+func (k kind) goType() string {
+	return k.String()
+}
+
+// reflectType returns the pipe value type, whose tag rules are listed by [v.TagRuleNames].
+func (k kind) reflectType() reflect.Type {
+	return [...]reflect.Type{reflect.TypeFor[string](), reflect.TypeFor[int](), reflect.TypeFor[float64](), reflect.TypeFor[time.Time]()}[k]
+}
+
+// pipe returns the name of the pipe constructor in package v.
+func (k kind) pipe() string {
+	return [...]string{"StringPipe", "IntPipe", "FloatPipe", "TimePipe"}[k]
+}
+
+// argKind tells how a rule argument is checked and written into the generated code.
+type argKind uint8
+
+const (
+	argNone argKind = iota
+	argInt
+	argFloat
+	argString
+	argRegexp
+	argList
+	argTime
+)
+
+// format checks arg and returns it as a Go expression.
+func (a argKind) format(arg string) (string, error) {
+	switch a {
+	case argNone:
+		if arg != "" {
+			return "", errors.New("takes no argument")
+		}
+		return "", nil
+	case argInt:
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return "", fmt.Errorf("%q is not an integer", arg)
+		}
+		return strconv.Itoa(n), nil
+	case argFloat:
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a number", arg)
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+	case argString:
+		if arg == "" {
+			return "", errors.New("needs an argument")
+		}
+		return strconv.Quote(arg), nil
+	case argRegexp:
+		if _, err := regexp.Compile(arg); err != nil {
+			return "", err
+		}
+		if strings.Contains(arg, "`") {
+			return strconv.Quote(arg), nil
+		}
+		return "`" + arg + "`", nil
+	case argList:
+		values := strings.Fields(arg)
+		if len(values) == 0 {
+			return "", errors.New("needs at least one value")
+		}
+		for i, value := range values {
+			values[i] = strconv.Quote(value)
+		}
+		return "[]string{" + strings.Join(values, ", ") + "}", nil
+	case argTime:
+		t, err := time.Parse(time.RFC3339, arg)
+		if err != nil {
+			return "", err
+		}
+		return timeLiteral(t), nil
+	}
+	return "", fmt.Errorf("unknown argument kind %d", a)
+}
+
+// timeLiteral returns a time.Date expression for t.
+func timeLiteral(t time.Time) string {
+	loc := "time.UTC"
+	if _, offset := t.Zone(); offset != 0 {
+		loc = fmt.Sprintf("time.FixedZone(\"\", %d)", offset)
+	}
+	return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// ruleSpec is the generated action of a rule. expr is a format string taking
+// the formatted argument, or the plain expression for rules without one.
+type ruleSpec struct {
+	expr      string
+	arg       argKind
+	transform bool
+}
+
+// ruleSpecs maps the rule names of every field kind to their actions.
+// It has an entry for every rule of [v.TagRuleNames], which decides the
+// rules a field accepts, so generated and run time rules can't drift apart.
+var ruleSpecs = map[kind]map[string]ruleSpec{
+	kindString: {
+		"required":   {expr: "v.NotEmpty()"},
+		"min":        {expr: "v.MinLength(%s)", arg: argInt},
+		"max":        {expr: "v.MaxLength(%s)", arg: argInt},
+		"email":      {expr: "v.IsEmail()"},
+		"url":        {expr: "v.IsURL()"},
+		"uuid":       {expr: "v.IsUUID()"},
+		"ulid":       {expr: "v.IsULID()"},
+		"alpha":      {expr: "v.IsAlpha()"},
+		"alphanum":   {expr: "v.IsAlphaNumeric()"},
+		"ascii":      {expr: "v.IsAscii()"},
+		"decimal":    {expr: "v.IsDecimal()"},
+		"ipv4":       {expr: "v.IsIPV4()"},
+		"ipv6":       {expr: "v.IsIPV6()"},
+		"json":       {expr: "v.IsJSON()"},
+		"base64":     {expr: "v.IsBase64()"},
+		"hexcolor":   {expr: "v.IsHexColor()"},
+		"creditcard": {expr: "v.IsCreditCard()"},
+		"prefix":     {expr: "v.HasPrefix(%s)", arg: argString},
+		"suffix":     {expr: "v.HasSuffix(%s)", arg: argString},
+		"contains":   {expr: "v.Contains(%s)", arg: argString},
+		"oneof":      {expr: "v.Enum(%s)", arg: argList},
+		"pattern":    {expr: "v.Pattern(%s)", arg: argRegexp},
+		"trim":       {expr: "v.Trim()", transform: true},
+		"lower":      {expr: "v.ToLower()", transform: true},
+		"upper":      {expr: "v.ToUpper()", transform: true},
+	},
+	kindInt: {
+		"required": {expr: "v.NonZero()"},
+		"min":      {expr: "v.Min(%s)", arg: argInt},
+		"max":      {expr: "v.Max(%s)", arg: argInt},
+		"gt":       {expr: "v.Gt(%s)", arg: argInt},
+		"gte":      {expr: "v.Gte(%s)", arg: argInt},
+		"lt":       {expr: "v.Lt(%s)", arg: argInt},
+		"lte":      {expr: "v.Lte(%s)", arg: argInt},
+		"positive": {expr: "v.IsPositive()"},
+		"negative": {expr: "v.IsNegative()"},
+	},
+	kindFloat: {
+		"required": {expr: "v.NonZeroFloat()"},
+		"min":      {expr: "v.MinFloat(%s)", arg: argFloat},
+		"max":      {expr: "v.MaxFloat(%s)", arg: argFloat},
+		"gt":       {expr: "v.GtFloat(%s)", arg: argFloat},
+		"gte":      {expr: "v.GteFloat(%s)", arg: argFloat},
+		"lt":       {expr: "v.LtFloat(%s)", arg: argFloat},
+		"lte":      {expr: "v.LteFloat(%s)", arg: argFloat},
+		"positive": {expr: "v.IsPositiveFloat()"},
+		"negative": {expr: "v.IsNegativeFloat()"},
+	},
+	kindTime: {
+		"required": {expr: "v.NotEmptyDate()"},
+		"before":   {expr: "v.Before(%s)", arg: argTime},
+		"after":    {expr: "v.After(%s)", arg: argTime},
+		"past":     {expr: "v.BeforeNow()"},
+		"future":   {expr: "v.AfterNow()"},
+		"weekday":  {expr: "v.IsWeekday()"},
+	},
+}
+
+// isKnownRule reports whether name is a rule of any field kind.
+func isKnownRule(name string) bool {
+	for k := range ruleSpecs {
+		if slices.Contains(v.TagRuleNames(k.reflectType()), name) {
+			return true
+		}
+	}
+	return false
+}
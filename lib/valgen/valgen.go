@@ -0,0 +1,376 @@
+// Package valgen generates Rules methods from valgo struct tags.
+//
+// The generated methods build the same pipes as the reflection based tag rules
+// of package v with [v.NewPipesBuilder] and [v.Entry], without any reflection
+// at run time. It is used by cmd/valgen through go generate.
+package valgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+// Generate parses the Go source file src and returns the source of a file,
+// in the same package, with a Rules method for every struct type that has
+// valgo tags, or for the listed types only.
+//
+// A field takes its rules from a `valgo:"..."` tag or, without a tag, from
+// a "valgo:" comment on the field. Generation fails on unknown rule names,
+// rules that don't apply to the field type, and invalid rule arguments.
+func Generate(filename string, src []byte, types ...string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		fset:    fset,
+		named:   make(map[string]ast.Expr),
+		methods: make(map[string]bool),
+	}
+	g.scan(file)
+
+	structs, err := g.selectStructs(file, types)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	for _, spec := range structs {
+		if err := g.writeRules(&body, spec); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by valgen. DO NOT EDIT.\n\npackage %s\n\n", file.Name.Name)
+	out.WriteString("import (\n")
+	if g.usesTime {
+		out.WriteString("\t\"time\"\n\n")
+	}
+	out.WriteString("\t\"github.com/mrbns/valgo/lib/v\"\n)\n")
+	out.Write(body.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+// generator holds what Generate learned about the parsed file.
+type generator struct {
+	fset *token.FileSet
+	// named maps the types declared in the file to their underlying type expressions.
+	named map[string]ast.Expr
+	// methods records the types that already declare a Rules method.
+	methods map[string]bool
+	// usesTime is set when the generated code refers to package time.
+	usesTime bool
+}
+
+func (g *generator) scan(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					g.named[ts.Name.Name] = ts.Type
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Recv != nil && d.Name.Name == "Rules" && len(d.Recv.List) == 1 {
+				g.methods[receiverName(d.Recv.List[0].Type)] = true
+			}
+		}
+	}
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// selectStructs returns the struct types to generate Rules for, in file order.
+func (g *generator) selectStructs(file *ast.File, types []string) ([]*ast.TypeSpec, error) {
+	var structs []*ast.TypeSpec
+	for _, decl := range file.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range d.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			if len(types) > 0 {
+				if slices.Contains(types, ts.Name.Name) {
+					structs = append(structs, ts)
+				}
+				continue
+			}
+			if hasRules(st) {
+				structs = append(structs, ts)
+			}
+		}
+	}
+
+	for _, name := range types {
+		if !slices.ContainsFunc(structs, func(ts *ast.TypeSpec) bool { return ts.Name.Name == name }) {
+			return nil, fmt.Errorf("valgen: struct type %s not found", name)
+		}
+	}
+	if len(structs) == 0 {
+		return nil, fmt.Errorf("valgen: no struct with valgo rules found")
+	}
+	return structs, nil
+}
+
+func hasRules(st *ast.StructType) bool {
+	return slices.ContainsFunc(st.Fields.List, func(f *ast.Field) bool {
+		_, ok := fieldRules(f)
+		return ok
+	})
+}
+
+// fieldRules returns the valgo rules of a field from its tag or its comments.
+func fieldRules(f *ast.Field) (string, bool) {
+	if f.Tag != nil {
+		tag, err := strconv.Unquote(f.Tag.Value)
+		if err == nil {
+			if rules, ok := reflect.StructTag(tag).Lookup("valgo"); ok {
+				return rules, rules != "-"
+			}
+		}
+	}
+	for _, group := range []*ast.CommentGroup{f.Doc, f.Comment} {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if rules, ok := strings.CutPrefix(text, "valgo:"); ok {
+				return strings.TrimSpace(rules), true
+			}
+		}
+	}
+	return "", false
+}
+
+// jsonKey returns the JSON name of a field, the key of its errors.
+func jsonKey(f *ast.Field, name string) string {
+	if f.Tag == nil {
+		return name
+	}
+	tag, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return name
+	}
+	key, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	if key == "" || key == "-" {
+		return name
+	}
+	return key
+}
+
+func (g *generator) writeRules(w *bytes.Buffer, ts *ast.TypeSpec) error {
+	name := ts.Name.Name
+	if g.methods[name] {
+		return fmt.Errorf("%s: %s already has a Rules method", g.fset.Position(ts.Pos()), name)
+	}
+
+	var entries []string
+	for _, f := range ts.Type.(*ast.StructType).Fields.List {
+		rules, ok := fieldRules(f)
+		if !ok || len(f.Names) == 0 {
+			continue
+		}
+		for _, ident := range f.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			entry, err := g.entry(f, ident.Name, rules)
+			if err != nil {
+				return fmt.Errorf("%s: %s.%s: %w", g.fset.Position(ident.Pos()), name, ident.Name, err)
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	fmt.Fprintf(w, "\n// Rules returns the validation rules of %s generated from its valgo tags.\n", name)
+	fmt.Fprintf(w, "func (s *%s) Rules() (v.PipeSet, error) {\n\treturn v.NewPipesBuilder(\n", name)
+	for _, entry := range entries {
+		fmt.Fprintf(w, "\t\t%s,\n", entry)
+	}
+	w.WriteString("\t), nil\n}\n")
+	fmt.Fprintf(w, "\n// ValgoGenerated tells package v that the valgo tags of %s are compiled into Rules.\n", name)
+	fmt.Fprintf(w, "func (*%s) ValgoGenerated() {}\n", name)
+	return nil
+}
+
+// entry returns the pipe entry expression of one field.
+func (g *generator) entry(f *ast.Field, name, tag string) (string, error) {
+	ft, err := g.resolve(f.Type)
+	if err != nil {
+		return "", err
+	}
+
+	rules := v.ParseTag(tag)
+	required := false
+	if ft.pointer {
+		rules = slices.DeleteFunc(rules, func(r v.TagRule) bool {
+			if r.Name == "required" {
+				required = true
+				return true
+			}
+			return false
+		})
+	}
+
+	actions, transforms, err := g.actions(ft.kind, rules)
+	if err != nil {
+		return "", err
+	}
+
+	if ft.kind == kindTime && ft.conversion != "" {
+		// a named time type is converted with time.Time(...)
+		g.usesTime = true
+	}
+
+	key := strconv.Quote(jsonKey(f, name))
+	field := "s." + name
+	args := strings.Join(append([]string{ft.convert(field)}, actions...), ", ")
+
+	switch {
+	case ft.pointer && transforms:
+		return "", fmt.Errorf("transform rules don't apply to pointer fields")
+	case ft.pointer && required:
+		return fmt.Sprintf("v.Entry(%s).Pipe(v.Required(%s))", key, args), nil
+	case ft.pointer:
+		return fmt.Sprintf("v.Entry(%s).Pipe(v.Optional(%s))", key, args), nil
+	case transforms && ft.conversion != "":
+		return "", fmt.Errorf("transform rules need a field of type %s, got %s", ft.kind, ft.conversion)
+	case transforms:
+		return fmt.Sprintf("v.Entry(%s).Pipe(v.%s(%s).Bind(&%s))", key, ft.kind.pipe(), args, field), nil
+	}
+	return fmt.Sprintf("v.Entry(%s).%s(%s)", key, ft.kind.pipe(), args), nil
+}
+
+// actions returns the action expressions of rules for a field of kind k.
+func (g *generator) actions(k kind, rules []v.TagRule) (actions []string, transforms bool, err error) {
+	for _, rule := range rules {
+		if !slices.Contains(v.TagRuleNames(k.reflectType()), rule.Name) {
+			if isKnownRule(rule.Name) {
+				return nil, false, fmt.Errorf("rule %q does not apply to %s fields", rule.Name, k)
+			}
+			return nil, false, fmt.Errorf("unknown rule %q", rule.Name)
+		}
+		spec, ok := ruleSpecs[k][rule.Name]
+		if !ok {
+			return nil, false, fmt.Errorf("rule %q of %s fields can't be generated", rule.Name, k)
+		}
+		arg, err := spec.arg.format(rule.Arg)
+		if err != nil {
+			return nil, false, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if spec.arg == argTime {
+			g.usesTime = true
+		}
+		if spec.arg == argNone {
+			actions = append(actions, spec.expr)
+		} else {
+			actions = append(actions, fmt.Sprintf(spec.expr, arg))
+		}
+		transforms = transforms || spec.transform
+	}
+	return actions, transforms, nil
+}
+
+// fieldType is a field type resolved to one of the pipe kinds.
+type fieldType struct {
+	kind    kind
+	pointer bool
+	// conversion is the declared type when the value must be converted
+	// to the pipe type, e.g. int8 for an IntPipe.
+	conversion string
+}
+
+// convert returns expr converted to the pipe type when needed.
+func (ft fieldType) convert(expr string) string {
+	if ft.conversion == "" {
+		return expr
+	}
+	return ft.kind.goType() + "(" + expr + ")"
+}
+
+// resolve maps a field type expression to a pipe kind.
+func (g *generator) resolve(expr ast.Expr) (fieldType, error) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		ft, err := g.resolve(star.X)
+		if err != nil {
+			return ft, err
+		}
+		if ft.pointer || ft.conversion != "" {
+			return ft, fmt.Errorf("unsupported type %s", typeString(expr))
+		}
+		ft.pointer = true
+		return ft, nil
+	}
+
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "time" && sel.Sel.Name == "Time" {
+			return fieldType{kind: kindTime}, nil
+		}
+		return fieldType{}, fmt.Errorf("unsupported type %s", typeString(expr))
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return fieldType{}, fmt.Errorf("unsupported type %s", typeString(expr))
+	}
+
+	switch ident.Name {
+	case "string":
+		return fieldType{kind: kindString}, nil
+	case "int":
+		return fieldType{kind: kindInt}, nil
+	case "float64":
+		return fieldType{kind: kindFloat}, nil
+	case "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return fieldType{kind: kindInt, conversion: ident.Name}, nil
+	case "float32":
+		return fieldType{kind: kindFloat, conversion: ident.Name}, nil
+	}
+
+	if underlying, ok := g.named[ident.Name]; ok {
+		ft, err := g.resolve(underlying)
+		if err != nil || ft.pointer {
+			return fieldType{}, fmt.Errorf("unsupported type %s", ident.Name)
+		}
+		ft.conversion = ident.Name
+		return ft, nil
+	}
+	return fieldType{}, fmt.Errorf("unsupported type %s", ident.Name)
+}
+
+func typeString(expr ast.Expr) string {
+	var b bytes.Buffer
+	if err := format.Node(&b, token.NewFileSet(), expr); err != nil {
+		return fmt.Sprintf("%T", expr)
+	}
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package tests_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestCoerceParsesAndValidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		pipe    v.PipeFace
+		wantErr string
+	}{
+		{"int", v.CoerceInt("42", v.Min(1)), ""},
+		{"int not a number", v.CoerceInt("4x2"), "must be an integer"},
+		{"int empty", v.CoerceInt(""), "must be an integer"},
+		{"int action", v.CoerceInt("0", v.Min(1)), "value must be at least"},
+		{"float", v.CoerceFloat("2.5", v.MaxFloat(3)), ""},
+		{"float invalid", v.CoerceFloat("two"), "must be a number"},
+		{"bool", v.CoerceBool("true"), ""},
+		{"bool invalid", v.CoerceBool("yes"), "must be a boolean"},
+		{"time", v.CoerceTime("2026-01-02", time.DateOnly), ""},
+		{"time invalid", v.CoerceTime("02/01/2026", time.DateOnly), "must be a time in the format 2006-01-02"},
+		{"duration", v.CoerceDuration("1h30m"), ""},
+		{"duration invalid", v.CoerceDuration("90"), "must be a duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pipe.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			var pipeErr *v.PipeError
+			if !errors.As(err, &pipeErr) {
+				t.Fatalf("expected PipeError, got %v", err)
+			}
+			if !strings.Contains(pipeErr.Err.Error(), tt.wantErr) {
+				t.Fatalf("expected %q, got %q", tt.wantErr, pipeErr.Err.Error())
+			}
+		})
+	}
+}
+
+func TestCoerceReturnsParsedValue(t *testing.T) {
+	if got := v.CoerceInt("42").Value(); got != 42 {
+		t.Fatalf("expected 42, got %d", got)
+	}
+	if got := v.CoerceFloat("2.5").Value(); got != 2.5 {
+		t.Fatalf("expected 2.5, got %v", got)
+	}
+	if got := v.CoerceBool("1").Value(); !got {
+		t.Fatal("expected true")
+	}
+	if got := v.CoerceDuration("1m").Value(); got != time.Minute {
+		t.Fatalf("expected 1m, got %v", got)
+	}
+	want := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	if got := v.CoerceTime("2026-01-02", time.DateOnly).Value(); !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := v.CoerceInt("150", v.Clamp(0, 100)).Value(); got != 100 {
+		t.Fatalf("expected 100, got %d", got)
+	}
+	if got := v.CoerceInt("nope").Value(); got != 0 {
+		t.Fatalf("expected 0, got %d", got)
+	}
+}
+
+func TestCoerceQueryParams(t *testing.T) {
+	query := url.Values{"page": {"0"}, "limit": {"500"}, "since": {"yesterday"}}
+
+	var page, limit int
+	err := v.NewPipesMap(v.PipeMap{
+		"page":  v.CoerceInt(query.Get("page"), v.Min(1)).Bind(&page),
+		"limit": v.CoerceInt(query.Get("limit"), v.Clamp(1, 100)).Bind(&limit),
+		"since": v.CoerceTime(query.Get("since"), time.DateOnly),
+	}).ValidateAll()
+
+	if got := partialErrorKeys(err); got != "page,since" {
+		t.Fatalf("expected page,since, got %q", got)
+	}
+	if page != 0 || limit != 100 {
+		t.Fatalf("expected page 0 and limit 100, got %d and %d", page, limit)
+	}
+}
+
+func TestCoerceCustomParser(t *testing.T) {
+	parse := func(s string) (uint16, error) {
+		var n uint16
+		for _, r := range s {
+			if r < '0' || r > '9' {
+				return 0, errors.New("must be a port number")
+			}
+			n = n*10 + uint16(r-'0')
+		}
+		return n, nil
+	}
+
+	if err := v.Coerce("80a", parse).Validate(); err == nil || !strings.Contains(err.Error(), "port") {
+		t.Fatalf("expected port error, got %v", err)
+	}
+	if got := v.Coerce("8080", parse).Value(); got != 8080 {
+		t.Fatalf("expected 8080, got %d", got)
+	}
+}
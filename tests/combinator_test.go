@@ -0,0 +1,121 @@
+package tests_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestAnyOf(t *testing.T) {
+	id := v.AnyOf(v.IsUUID(), v.IsULID())
+
+	for _, value := range []string{"550e8400-e29b-41d4-a716-446655440000", "01ARZ3NDEKTSV4RRFFQ69G5FAV"} {
+		if err := id.Run(value); err != nil {
+			t.Fatalf("expected %q to pass, got %v", value, err)
+		}
+	}
+
+	err := id.Run("nope")
+	want := "not a valid value, any of: not a valid UUID; not a valid ULID"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+}
+
+func TestAllOfListsEveryFailure(t *testing.T) {
+	err := v.AllOf(v.MinLength(10), v.IsAlpha(v.ErrMsg("letters only"))).Run("ab1")
+	want := "not a valid value, all of: string length must be at least specified minimum; letters only"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+
+	if err := v.AllOf(v.MinLength(2), v.IsAlpha()).Run("abc"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestExactlyOneOf(t *testing.T) {
+	even := v.CustomNumber(func(n int) bool { return n%2 == 0 }, v.ErrMsg("must be even"))
+	big := v.Min(100, v.ErrMsg("must be at least 100"))
+	one := v.ExactlyOneOf(even, big)
+
+	if err := one.Run(4); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := one.Run(200); err == nil || err.Error() != "must match exactly one alternative, matched 2" {
+		t.Fatalf("expected matched 2 error, got %v", err)
+	}
+	if err := one.Run(3); err == nil || err.Error() != "not a valid value, exactly one of: must be even; must be at least 100" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestNot(t *testing.T) {
+	host := v.Not(v.IsIPV4(), v.ErrMsg("use a host name"))
+
+	if err := host.Run("example.com"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := host.Run("10.0.0.1"); err == nil || err.Error() != "use a host name" {
+		t.Fatalf("expected custom message, got %v", err)
+	}
+	if err := v.Not(v.IsIPV4()).Run("10.0.0.1"); err == nil || err.Error() != "value is not allowed" {
+		t.Fatalf("expected default message, got %v", err)
+	}
+}
+
+func TestWithMsgOverridesCombinator(t *testing.T) {
+	inner := errors.New("not a ULID")
+	ulid := v.ActionFunc[string](func(string) error { return inner })
+
+	err := v.StringPipe("nope", v.WithMsg(v.AnyOf(v.IsUUID(), ulid), v.ErrMsg("{VALUE} must be a UUID or ULID"))).Validate()
+
+	var pipeErr *v.PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.Err.Error() != "nope must be a UUID or ULID" {
+		t.Fatalf("expected custom message, got %v", err)
+	}
+	if !errors.Is(err, inner) {
+		t.Fatal("expected the alternatives to stay in the error chain")
+	}
+}
+
+func TestCombinatorRunsActionsLikeAPipe(t *testing.T) {
+	if err := v.AllOf(v.Trim(), v.MinLength(3), v.MaxLength(3)).Run(" abc "); err != nil {
+		t.Fatalf("expected the trimmed value to pass, got %v", err)
+	}
+
+	err := v.NewPipesBuilder(
+		v.Entry("emails").Pipe(v.SlicePipe([]string{"a@b.co", "x", "y"}, v.AllOf(v.MinItems[string](1), v.Each(v.IsEmail())))),
+	).ValidateAll()
+	want := "emails: not a valid value, all of: [1]: not a valid email, [2]: not a valid email"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+}
+
+func TestExactlyOneOfReportsMatchesWithACode(t *testing.T) {
+	even := v.CustomNumber(func(n int) bool { return n%2 == 0 })
+	one := v.ExactlyOneOf(even, v.Min(100))
+
+	var actionErr *v.ActionError
+	err := one.Run(200)
+	if !errors.As(err, &actionErr) || actionErr.Code != "combinator.one_of" || actionErr.Params["matched"] != 2 {
+		t.Fatalf("expected a combinator.one_of error with 2 matches, got %#v", err)
+	}
+
+	err = v.WithMsg(one, v.ErrMsg("{VALUE} matched {PARAM.matched} rules")).Run(200)
+	if err == nil || err.Error() != "200 matched 2 rules" {
+		t.Fatalf("expected the custom message, got %v", err)
+	}
+}
+
+func TestCombinatorWithoutAlternatives(t *testing.T) {
+	for name, action := range map[string]v.Action[string]{"AnyOf": v.AnyOf[string](), "ExactlyOneOf": v.ExactlyOneOf[string]()} {
+		var actionErr *v.ActionError
+		err := action.Run("x")
+		if !errors.As(err, &actionErr) || actionErr.Code != "combinator.empty" || err.Error() != "no alternatives to match" {
+			t.Fatalf("%s: expected a combinator.empty error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package tests_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type compiledUser struct {
+	Name            string
+	Email           string
+	Age             int
+	Rating          float64
+	Birthday        time.Time
+	Password        string
+	PasswordConfirm string
+	Tags            []string
+}
+
+var compiledUserSchema = v.Compile(
+	v.StringField("name", func(u *compiledUser) string { return u.Name }, v.NotEmpty(), v.Pattern(`^[A-Za-z ]+$`)),
+	v.StringField("email", func(u *compiledUser) string { return u.Email }, v.NotEmpty(), v.IsEmail()),
+	v.IntField("age", func(u *compiledUser) int { return u.Age }, v.Min(18)),
+	v.FloatField("rating", func(u *compiledUser) float64 { return u.Rating }, v.MaxFloat(5)),
+	v.TimeField("birthday", func(u *compiledUser) time.Time { return u.Birthday }, v.BeforeNow()),
+	v.Field("tags", func(u *compiledUser) []string { return u.Tags }, v.MaxItems[string](2), v.Each(v.NotEmpty())),
+	v.Check("password_confirm", func(u *compiledUser) error {
+		if u.Password != u.PasswordConfirm {
+			return errors.New("must match password")
+		}
+		return nil
+	}),
+)
+
+func validCompiledUser() compiledUser {
+	return compiledUser{
+		Name:     "Jane Doe",
+		Email:    "jane@example.com",
+		Age:      30,
+		Rating:   4.5,
+		Birthday: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Tags:     []string{"a"},
+	}
+}
+
+func TestCompiledSchema(t *testing.T) {
+	user := validCompiledUser()
+	if err := compiledUserSchema.ValidateAll(&user); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	bad := compiledUser{Name: "J4ne", Email: "nope", Age: 12, Rating: 9, Birthday: time.Now().Add(time.Hour), Password: "a", Tags: []string{"x", ""}}
+	err := compiledUserSchema.ValidateAll(&bad)
+	if got := partialErrorKeys(err); got != "name,email,age,rating,birthday,tags[1],password_confirm" {
+		t.Fatalf("unexpected keys %q (%v)", got, err)
+	}
+
+	var pipeErr *v.PipeError
+	if err := compiledUserSchema.Validate(&bad); !errors.As(err, &pipeErr) || pipeErr.Key != "name" {
+		t.Fatalf("expected the first error on name, got %v", err)
+	}
+}
+
+func TestCompiledSchemaConcurrentUse(t *testing.T) {
+	var wg sync.WaitGroup
+	errs := make([]error, 64)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			user := validCompiledUser()
+			user.Age = 10 + i
+			err := compiledUserSchema.ValidateAll(&user)
+			switch {
+			case user.Age < 18 && partialErrorKeys(err) != "age":
+				errs[i] = fmt.Errorf("age %d: expected an age error, got %v", user.Age, err)
+			case user.Age >= 18 && err != nil:
+				errs[i] = fmt.Errorf("age %d: expected nil, got %v", user.Age, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		t.Fatal(err)
+	}
+}
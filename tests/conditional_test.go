@@ -0,0 +1,108 @@
+package tests_test
+
+import (
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type shippingSchema struct {
+	Country    string  `json:"country"`
+	PostalCode string  `json:"postal_code"`
+	State      string  `json:"state"`
+	Type       string  `json:"type"`
+	VatID      string  `json:"vat_id"`
+	Amount     float64 `json:"amount"`
+	Note       string  `json:"note"`
+}
+
+func (s *shippingSchema) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"country": v.StringPipe(s.Country, v.NotEmpty()),
+		"postal_code": v.Switch("country", v.Cases{
+			"US": {v.StringPipe(s.PostalCode, v.Pattern(`^\d{5}$`))},
+			"BD": {v.StringPipe(s.PostalCode, v.Pattern(`^\d{4}$`))},
+		}, v.StringPipe(s.PostalCode, v.NotEmpty())),
+		"state":  v.When(v.FieldIn("country", "US", "CA"), v.StringPipe(s.State, v.NotEmpty())),
+		"type":   v.StringPipe(s.Type),
+		"vat_id": v.Unless(v.FieldEquals("type", "person"), v.StringPipe(s.VatID, v.MinLength(5))),
+		"amount": v.FloatPipe(s.Amount),
+		"note":   v.When(v.FieldEquals("amount", 100), v.StringPipe(s.Note, v.NotEmpty())),
+	}), nil
+}
+
+func TestConditionalPipesInPipeMap(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"us valid", `{"country":"US","postal_code":"12345","state":"NY","type":"person"}`, ""},
+		{"us bad zip and state", `{"country":"US","postal_code":"1234","type":"person"}`, "postal_code,state"},
+		{"bd zip", `{"country":"BD","postal_code":"1207","type":"person"}`, ""},
+		{"bd bad zip", `{"country":"BD","postal_code":"12070","type":"person"}`, "postal_code"},
+		{"otherwise", `{"country":"DE","type":"person"}`, "postal_code"},
+		{"company vat", `{"country":"DE","postal_code":"10115","type":"company","vat_id":"DE1"}`, "vat_id"},
+		{"number condition", `{"country":"DE","postal_code":"10115","type":"person","amount":100}`, "note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := partialErrorKeys(v.ParseBytesFull([]byte(tt.data), &shippingSchema{})); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConditionalPipesInBuilder(t *testing.T) {
+	build := func(kind, vatID, company string) v.PipeSet {
+		return v.NewPipesBuilder(
+			v.Entry("type").StringPipe(kind, v.NotEmpty()),
+			v.When(v.FieldEquals("type", "company"),
+				v.Entry("vat_id").StringPipe(vatID, v.NotEmpty()),
+				v.Entry("company").StringPipe(company, v.NotEmpty()),
+			),
+			v.RequiredWith("company", []string{"vat_id"}),
+		)
+	}
+
+	if err := build("person", "", "").ValidateAll(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	if got := partialErrorKeys(build("company", "", "").ValidateAll()); got != "vat_id,company" {
+		t.Fatalf("expected vat_id,company, got %q", got)
+	}
+
+	// siblings can read fields inside a conditional group
+	if got := partialErrorKeys(build("company", "DE123", "").ValidateAll()); got != "company,company" {
+		t.Fatalf("expected company,company, got %q", got)
+	}
+
+	err := build("company", "", "").Validate()
+	pipeErr, ok := err.(*v.PipeError)
+	if !ok || pipeErr.Key != "vat_id" {
+		t.Fatalf("expected first error on vat_id, got %v", err)
+	}
+}
+
+func TestConditionalPipeStandalone(t *testing.T) {
+	pipe := v.When(v.FieldSet("country"), v.Entry("zip").StringPipe("", v.NotEmpty()))
+	if err := pipe.Validate(); err != nil {
+		t.Fatalf("expected nil without siblings, got %v", err)
+	}
+
+	pipe = v.Unless(v.FieldSet("country"), v.Entry("zip").StringPipe("", v.NotEmpty()))
+	if err := pipe.Validate(); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestConditionalPipesParallel(t *testing.T) {
+	schema := &shippingSchema{Country: "US", PostalCode: "1", Type: "company"}
+	got := partialErrorKeys(v.ValidateAllParallel(schema, v.Concurrency(4)))
+	if got != "postal_code,state,vat_id" {
+		t.Fatalf("expected postal_code,state,vat_id, got %q", got)
+	}
+}
@@ -0,0 +1,128 @@
+package tests_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type signupSchema struct {
+	Password        string    `json:"password"`
+	PasswordConfirm string    `json:"password_confirm"`
+	Email           string    `json:"email"`
+	Phone           *string   `json:"phone"`
+	Coupon          string    `json:"coupon"`
+	GiftCard        string    `json:"gift_card"`
+	StartDate       time.Time `json:"start_date"`
+	EndDate         time.Time `json:"end_date"`
+}
+
+func (s *signupSchema) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"password":         v.StringPipe(s.Password, v.MinLength(8)),
+		"password_confirm": v.StringPipe(s.PasswordConfirm),
+		"email":            v.StringPipe(s.Email),
+		"phone":            v.Optional(s.Phone),
+		"coupon":           v.StringPipe(s.Coupon),
+		"gift_card":        v.StringPipe(s.GiftCard),
+		"start_date":       v.TimePipe(s.StartDate),
+		"end_date":         v.TimePipe(s.EndDate),
+	}, v.WithRules(
+		v.EqualField("password_confirm", "password"),
+		v.AtLeastOneOf([]string{"email", "phone"}),
+		v.MutuallyExclusive([]string{"coupon", "gift_card"}),
+		v.AfterField("end_date", "start_date"),
+	)), nil
+}
+
+func TestCrossFieldRulesWithPipesMap(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"valid", `{"password":"secret123","password_confirm":"secret123","phone":"+880","start_date":"2026-01-01T00:00:00Z","end_date":"2026-02-01T00:00:00Z"}`, ""},
+		{"mismatch", `{"password":"secret123","password_confirm":"secret12","email":"a@b.c"}`, "password_confirm"},
+		{"no contact", `{"password":"secret123","password_confirm":"secret123"}`, "email"},
+		{"both discounts", `{"password":"secret123","password_confirm":"secret123","email":"a@b.c","coupon":"X","gift_card":"Y"}`, "gift_card"},
+		{"end before start", `{"password":"secret123","password_confirm":"secret123","email":"a@b.c","start_date":"2026-02-01T00:00:00Z","end_date":"2026-01-01T00:00:00Z"}`, "end_date"},
+		{"field and rule errors", `{"password":"short","password_confirm":""}`, "password,password_confirm,email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := partialErrorKeys(v.ParseBytesFull([]byte(tt.data), &signupSchema{})); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRequiredWithAndWithout(t *testing.T) {
+	build := func(street, city, email, phone string) v.PipeSet {
+		return v.NewPipesBuilder(
+			v.Entry("street").StringPipe(street),
+			v.Entry("city").StringPipe(city),
+			v.Entry("email").StringPipe(email),
+			v.Entry("phone").StringPipe(phone),
+			v.RequiredWith("city", []string{"street"}),
+			v.RequiredWithout("phone", []string{"email"}),
+		)
+	}
+
+	if err := build("", "", "a@b.c", "").ValidateAll(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	err := build("Main St", "", "", "").ValidateAll()
+	if got := partialErrorKeys(err); got != "city,phone" {
+		t.Fatalf("expected city,phone, got %q", got)
+	}
+	if !errors.Is(err, v.ErrRequired) {
+		t.Fatalf("expected v.ErrRequired, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "city: is required when street is set") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
+
+func TestCrossFieldRulesAcceptOptions(t *testing.T) {
+	err := v.NewPipesBuilder(
+		v.Entry("street").StringPipe("Main St"),
+		v.Entry("city").StringPipe(""),
+		v.Entry("email").StringPipe(""),
+		v.Entry("phone").StringPipe(""),
+		v.Entry("coupon").StringPipe("X"),
+		v.Entry("gift_card").StringPipe("Y"),
+		v.RequiredWith("city", []string{"street"}, v.ErrMsg("needed with {PARAM.field}")),
+		v.RequiredWithout("phone", []string{"email"}, v.ErrMsg("give a phone or an email")),
+		v.MutuallyExclusive([]string{"coupon", "gift_card"}, v.ErrMsg("can't be used with {PARAM.field}")),
+		v.AtLeastOneOf([]string{"email", "phone"}, v.ErrMsg("give a way to reach you")),
+	).ValidateAll()
+
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	want := map[string]string{
+		"city":      "needed with street",
+		"phone":     "give a phone or an email",
+		"gift_card": "can't be used with coupon",
+		"email":     "give a way to reach you",
+	}
+	for _, e := range errs {
+		if msg, ok := want[e.Key]; !ok || e.Err.Error() != msg {
+			t.Fatalf("%s: expected %q, got %q", e.Key, msg, e.Err.Error())
+		}
+		if e.Key == "city" && !errors.Is(e, v.ErrRequired) {
+			t.Fatalf("expected RequiredWith to wrap v.ErrRequired, got %v", e)
+		}
+		delete(want, e.Key)
+	}
+	if len(want) > 0 {
+		t.Fatalf("missing errors for %v", want)
+	}
+}
@@ -0,0 +1,134 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestActionErrorCodeAndParams(t *testing.T) {
+	err := v.NewPipesBuilder(
+		v.Entry("name").StringPipe("ab", v.MinLength(3, v.ErrMsg("too short"))),
+	).Validate()
+
+	var actionErr *v.ActionError
+	if !errors.As(err, &actionErr) {
+		t.Fatalf("expected an ActionError, got %T", err)
+	}
+	if actionErr.Code != "string.min_length" || actionErr.Params["min"] != 3 || actionErr.Value != "ab" {
+		t.Fatalf("unexpected action error %+v", actionErr)
+	}
+	if actionErr.Msg != "too short" {
+		t.Fatalf("expected custom message, got %q", actionErr.Msg)
+	}
+}
+
+func TestActionErrorCodes(t *testing.T) {
+	deadline := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		err  error
+		code string
+	}{
+		{v.IsEmail().Run("nope"), "string.email"},
+		{v.Min(18).Run(3), "int.min"},
+		{v.MaxFloat(1.5).Run(2), "float.max"},
+		{v.Before(deadline).Run(deadline.Add(time.Hour)), "time.before"},
+		{v.WithMsg(v.IsUUID(), v.ErrMsg("bad id")).Run("x"), "string.uuid"},
+	}
+	for _, c := range cases {
+		var actionErr *v.ActionError
+		if !errors.As(c.err, &actionErr) || actionErr.Code != c.code {
+			t.Fatalf("expected code %s, got %v", c.code, c.err)
+		}
+	}
+}
+
+type floatTagPrice struct {
+	v.Include
+	Amount float64 `json:"amount" valgo:"required"`
+}
+
+func TestBuiltInFailureCodes(t *testing.T) {
+	cases := []struct {
+		err  error
+		code string
+	}{
+		{v.Required[int](nil).Validate(), "required"},
+		{v.NewPipesBuilder(
+			v.Entry("email").StringPipe("", v.Trim()),
+			v.Entry("phone").StringPipe("123"),
+			v.RequiredWith("email", []string{"phone"}),
+		).Validate(), "field.required_with"},
+		{v.NewPipesBuilder(
+			v.Entry("password").StringPipe("a"),
+			v.Entry("password_confirm").StringPipe("b"),
+			v.EqualField("password_confirm", "password"),
+		).Validate(), "field.equal"},
+		{v.CoerceInt("ten").Validate(), "int.parse"},
+		{v.CoerceTime("soon", time.DateOnly).Validate(), "time.parse"},
+		{v.SlicePipe([]int{1}, v.MinItems[int](2)).Validate(), "slice.min_items"},
+		{v.SlicePipe([]int{1, 1}, v.UniqueItems[int]()).Validate(), "slice.unique_items"},
+		{v.MapPipe(map[string]int{"a": 1, "b": 2}, v.MaxKeys[string, int](1)).Validate(), "map.max_keys"},
+		{v.MapPipe(map[string]int{}, v.RequiredKeys[string, int]("region")).Validate(), "map.required_key"},
+		{v.MapPipe(map[string]int{"internal": 1}, v.ForbiddenKeys[string, int]("internal")).Validate(), "map.forbidden_key"},
+		{v.Validate(&floatTagPrice{}), "float.non_zero"},
+	}
+	for _, c := range cases {
+		var actionErr *v.ActionError
+		if !errors.As(c.err, &actionErr) || actionErr.Code != c.code {
+			t.Fatalf("expected code %s, got %v", c.code, c.err)
+		}
+	}
+}
+
+func TestRequiredCodeWrapsErrRequired(t *testing.T) {
+	err := v.Required[string](nil).Validate()
+	var actionErr *v.ActionError
+	if !errors.As(err, &actionErr) || !errors.Is(err, v.ErrRequired) {
+		t.Fatalf("expected an ActionError wrapping v.ErrRequired, got %v", err)
+	}
+	if err.Error() != "is required" {
+		t.Fatalf("expected the message of v.ErrRequired, got %q", err.Error())
+	}
+}
+
+func TestPipeErrorJSONCode(t *testing.T) {
+	err := v.NewPipesBuilder(
+		v.Entry("age").IntPipe(10, v.Min(18)),
+		v.Entry("id").StringPipe("x", v.AnyOf(v.IsUUID(), v.IsULID())),
+		v.Entry("plan").StringPipe("x", v.NewAction("unknown plan", func(string) bool { return false })),
+	).ValidateAll()
+
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("marshal: %v", jsonErr)
+	}
+
+	want := `[` +
+		`{"code":"int.min","key":"age","msg":"value must be at least specified minimum","params":{"min":18}},` +
+		`{"key":"id","msg":"not a valid value, any of: not a valid UUID; not a valid ULID"},` +
+		`{"key":"plan","msg":"unknown plan"}` +
+		`]`
+	if string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+}
+
+func TestActionParamsBuiltOnFailure(t *testing.T) {
+	allocs := func(value int) float64 {
+		return testing.AllocsPerRun(20, func() {
+			_ = v.Max(10).Run(value)
+		})
+	}
+
+	// the params map and the message are only needed to report a failure,
+	// so building and running an action that passes must not pay for them.
+	passed, failed := allocs(5), allocs(20)
+	if passed*2 > failed {
+		t.Fatalf("expected a passing action to skip its params, got %v allocs vs %v", passed, failed)
+	}
+}
@@ -0,0 +1,145 @@
+package tests_test
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+//go:embed testdata/locales/*.json
+var locales embed.FS
+
+func newTestTranslator(t *testing.T) *v.Translator {
+	t.Helper()
+	translator := v.NewTranslator("en")
+	if err := translator.LoadFS(locales, "testdata/locales/*.json"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	return translator
+}
+
+type i18nUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+func (u *i18nUser) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("name").StringPipe(u.Name, v.MinLength(3), v.IsAlpha()),
+		v.Entry("email").StringPipe(u.Email, v.IsEmail()),
+		v.Entry("age").IntPipe(u.Age, v.Min(18)),
+	), nil
+}
+
+func TestLocaleFallbackChain(t *testing.T) {
+	translator := newTestTranslator(t)
+	user := &i18nUser{Name: "ab", Email: "nope", Age: 12}
+
+	err := translator.Locale("bn-BD").ValidateAll(user)
+	want := "name: must be at least 3 characters, " +
+		"email: সঠিক ইমেইল নয়, " +
+		"age: বয়স কমপক্ষে 18 বছর হতে হবে"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+
+	err = translator.Locale("bn_IN").ValidateAll(user)
+	if errs, ok := err.(v.ValidationErrors); !ok || errs[2].Error() != "age: কমপক্ষে 18 হতে হবে" {
+		t.Fatalf("expected the bn message, got %v", err)
+	}
+
+	err = translator.Locale("fr").Validate(user)
+	if err == nil || err.Error() != "name: must be at least 3 characters" {
+		t.Fatalf("expected the en fallback, got %v", err)
+	}
+}
+
+func TestLocalePluralAndKey(t *testing.T) {
+	translator := newTestTranslator(t)
+	en := translator.Locale("en-US")
+
+	err := en.Localize(v.NewPipesBuilder(v.Entry("code").StringPipe("", v.MinLength(1))).Validate())
+	if err == nil || err.Error() != "code: must be at least 1 character" {
+		t.Fatalf("expected the singular form, got %v", err)
+	}
+
+	err = en.Localize(v.NewPipesBuilder(v.Entry("contact").StringPipe("x", v.IsEmail())).Validate())
+	if err == nil || err.Error() != "contact: contact must be an email address" {
+		t.Fatalf("expected {KEY} to be filled, got %v", err)
+	}
+}
+
+func TestLocalizeKeepsCustomMessages(t *testing.T) {
+	bn := newTestTranslator(t).Locale("bn")
+
+	err := bn.Localize(v.NewPipesBuilder(
+		v.Entry("email").StringPipe("nope", v.IsEmail(v.ErrMsg("use your work email"))),
+		v.Entry("backup").StringPipe("nope", v.WithMsg(v.IsEmail(), v.ErrMsg("use another email"))),
+		v.Entry("contact").StringPipe("nope", v.IsEmail()),
+	).ValidateAll())
+
+	want := "email: use your work email, backup: use another email, contact: সঠিক ইমেইল নয়"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+}
+
+func TestLocalizeKeepsCodesAndDefaults(t *testing.T) {
+	translator := newTestTranslator(t)
+	bn := translator.Locale("bn")
+
+	err := bn.Localize(v.ParseBytesFull([]byte(`{"name":"abc1","email":"nope","age":20}`), &i18nUser{}))
+
+	var actionErr *v.ActionError
+	if !errors.As(err, &actionErr) || actionErr.Code != "string.alpha" {
+		t.Fatalf("expected the string.alpha error, got %v", err)
+	}
+
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("marshal: %v", jsonErr)
+	}
+	want := `{"validation_error":[` +
+		`{"code":"string.alpha","key":"name","msg":"must contain only alphabetic characters"},` +
+		`{"code":"string.email","key":"email","msg":"সঠিক ইমেইল নয়"}]}`
+	if string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+}
+
+func TestPluralRules(t *testing.T) {
+	catalog := v.NewCatalog("ru", nil).Set("slice.min_items", v.Message{
+		Count: "min",
+		Forms: map[string]string{
+			v.PluralOne:   "{PARAM.min} элемент",
+			v.PluralFew:   "{PARAM.min} элемента",
+			v.PluralMany:  "{PARAM.min} элементов",
+			v.PluralOther: "{PARAM.min} элемента",
+		},
+	})
+	ru := v.NewTranslator("en", catalog).Locale("ru")
+
+	for min, want := range map[int]string{1: "1 элемент", 3: "3 элемента", 5: "5 элементов", 21: "21 элемент"} {
+		err := ru.Localize(&v.ActionError{Code: "slice.min_items", Msg: "too few", Params: map[string]any{"min": min}})
+		if err.Error() != want {
+			t.Fatalf("expected %q, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestParseCatalogNull(t *testing.T) {
+	catalog, err := v.ParseCatalog("bn", []byte("null"))
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	catalog.Set("int.min", v.Message{Forms: map[string]string{v.PluralOther: "কমপক্ষে {PARAM.min}"}})
+
+	err = v.NewTranslator("bn", catalog).Locale("bn").Localize(v.NewPipesBuilder(v.Entry("age").IntPipe(12, v.Min(18))).Validate())
+	if err == nil || err.Error() != "age: কমপক্ষে 18" {
+		t.Fatalf("expected the message set on the catalog, got %v", err)
+	}
+}
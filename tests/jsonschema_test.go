@@ -0,0 +1,219 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type jsonSchemaSignup struct {
+	Email    string            `json:"email"`
+	Name     string            `json:"name"`
+	Age      int               `json:"age"`
+	Nickname *string           `json:"nickname"`
+	Plan     string            `json:"plan"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+}
+
+func (s *jsonSchemaSignup) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("email").StringPipe(s.Email, v.NotEmpty(), v.IsEmail()),
+		v.Entry("name").StringPipe(s.Name, v.MinLength(2), v.Pattern(`^[A-Za-z ]+$`)),
+		v.Entry("age").IntPipe(s.Age, v.Min(18), v.Max(130)),
+		v.Entry("nickname").Pipe(v.Optional(s.Nickname, v.MaxLength(20))),
+		v.Entry("plan").StringPipe(s.Plan, v.Enum([]string{"free", "pro"})),
+		v.Entry("tags").Pipe(v.SlicePipe(s.Tags, v.MaxItems[string](5), v.Each(v.MinLength(1)))),
+		v.Entry("labels").Pipe(v.MapPipe(s.Labels, v.Values[string](v.MaxLength(64)))),
+	), nil
+}
+
+// roundTrip returns schema as decoded JSON, the form clients see.
+func roundTrip(t *testing.T, schema any) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return out
+}
+
+func TestJSONSchemaOf(t *testing.T) {
+	schema, err := v.JSONSchemaOf(&jsonSchemaSignup{})
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	got := roundTrip(t, schema)
+
+	want := map[string]any{
+		"$schema": v.JSONSchemaDialect,
+		"type":    "object",
+		"properties": map[string]any{
+			"email":    map[string]any{"type": "string", "minLength": 1.0, "format": "email"},
+			"name":     map[string]any{"type": "string", "minLength": 2.0, "pattern": "^[A-Za-z ]+$"},
+			"age":      map[string]any{"type": "integer", "minimum": 18.0, "maximum": 130.0},
+			"nickname": map[string]any{"type": []any{"string", "null"}, "maxLength": 20.0},
+			"plan":     map[string]any{"type": "string", "enum": []any{"free", "pro"}},
+			"tags": map[string]any{
+				"type":     "array",
+				"maxItems": 5.0,
+				"items":    map[string]any{"type": "string", "minLength": 1.0},
+			},
+			"labels": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "string", "maxLength": 64.0},
+			},
+		},
+		"required": []any{"email"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Fatalf("unexpected schema:\n%s", gotJSON)
+	}
+}
+
+func TestJSONSchemaCustomActions(t *testing.T) {
+	currency := v.CustomString(func(s string) bool { return s == "EUR" }, v.ErrMsg("must be a supported currency"))
+
+	schema := v.ActionJSONSchema(currency)
+	if got := schema[v.CustomKeyword]; !reflect.DeepEqual(got, []string{"must be a supported currency"}) {
+		t.Fatalf("expected custom action under %s, got %v", v.CustomKeyword, schema)
+	}
+
+	declared := v.WithSchema(currency, v.JSONSchema{"enum": []string{"EUR"}})
+	if err := declared.Run("USD"); err == nil {
+		t.Fatal("expected WithSchema to keep validating")
+	}
+	if got := v.ActionJSONSchema(declared); !reflect.DeepEqual(got, v.JSONSchema{"enum": []string{"EUR"}}) {
+		t.Fatalf("expected declared schema, got %v", got)
+	}
+}
+
+func TestJSONSchemaCombinators(t *testing.T) {
+	got := roundTrip(t, v.ActionJSONSchema(v.AnyOf(v.IsUUID(), v.Not(v.IsIPV4()))))
+	want := map[string]any{
+		"anyOf": []any{
+			map[string]any{"format": "uuid"},
+			map[string]any{"not": map[string]any{"format": "ipv4"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+type jsonSchemaAddress struct {
+	City string `json:"city" valgo:"required,max=40"`
+}
+
+func (a *jsonSchemaAddress) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(), nil
+}
+
+type jsonSchemaOrder struct {
+	Address *jsonSchemaAddress `json:"address"`
+}
+
+func (o *jsonSchemaOrder) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("address").Pipe(v.SchemaPipe(o.Address)),
+	), nil
+}
+
+func TestJSONSchemaNestedAndTags(t *testing.T) {
+	schema, err := v.JSONSchemaOf(&jsonSchemaOrder{})
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	got := roundTrip(t, schema)["properties"].(map[string]any)["address"]
+
+	want := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"city": map[string]any{"type": "string", "minLength": 1.0, "maxLength": 40.0},
+		},
+		"required": []any{"city"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestJSONSchemaConditionals(t *testing.T) {
+	schema, err := v.JSONSchemaOf(&shippingSchema{})
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	got := roundTrip(t, schema)
+
+	// the When and Unless pipes of state, vat_id and note are left out,
+	// the Switch on country becomes one if/then per case plus otherwise
+	want := map[string]any{
+		"$schema": v.JSONSchemaDialect,
+		"type":    "object",
+		"properties": map[string]any{
+			"country": map[string]any{"type": "string", "minLength": 1.0},
+			"type":    map[string]any{"type": "string"},
+			"amount":  map[string]any{"type": "number"},
+		},
+		"required": []any{"country"},
+		"allOf": []any{
+			map[string]any{
+				"if": map[string]any{
+					"properties": map[string]any{"country": map[string]any{"const": "BD"}},
+					"required":   []any{"country"},
+				},
+				"then": map[string]any{
+					"properties": map[string]any{"postal_code": map[string]any{"type": "string", "pattern": `^\d{4}$`}},
+				},
+			},
+			map[string]any{
+				"if": map[string]any{
+					"properties": map[string]any{"country": map[string]any{"const": "US"}},
+					"required":   []any{"country"},
+				},
+				"then": map[string]any{
+					"properties": map[string]any{"postal_code": map[string]any{"type": "string", "pattern": `^\d{5}$`}},
+				},
+			},
+			map[string]any{
+				"if": map[string]any{
+					"properties": map[string]any{"country": map[string]any{"not": map[string]any{"enum": []any{"BD", "US"}}}},
+				},
+				"then": map[string]any{
+					"properties": map[string]any{"postal_code": map[string]any{"type": "string", "minLength": 1.0}},
+					"required":   []any{"postal_code"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Fatalf("unexpected schema:\n%s", gotJSON)
+	}
+}
+
+func TestJSONSchemaWhenIsLeftOut(t *testing.T) {
+	var country, zip string
+	schema, err := v.JSONSchemaOfSet(v.PipeMap{
+		"country": v.StringPipe(country),
+		"zip":     v.When(v.FieldEquals("country", "US"), v.StringPipe(zip, v.NotEmpty(), v.MaxLength(5))),
+	})
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	want := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"country": map[string]any{"type": "string"}},
+	}
+	if got := roundTrip(t, schema); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
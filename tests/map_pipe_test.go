@@ -0,0 +1,74 @@
+package tests_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestMapPipeKeysAndValues(t *testing.T) {
+	metadata := map[string]string{
+		"region":  "",
+		"team":    "core",
+		"bad-key": "x",
+	}
+
+	set := v.NewPipesMap(v.PipeMap{
+		"metadata": v.MapPipe(metadata,
+			v.MaxKeys[string, string](5),
+			v.Keys[string, string](v.IsAlpha()),
+			v.Values[string](v.NotEmpty()),
+		),
+	})
+
+	var first *v.PipeError
+	if !errors.As(set.Validate(), &first) || first.Key != `metadata["bad-key"]` {
+		t.Fatalf(`expected metadata["bad-key"], got %v`, first)
+	}
+	wantPath := v.Path{v.PathKey("metadata"), v.PathMapKey("bad-key")}
+	if !reflect.DeepEqual(first.Path, wantPath) {
+		t.Fatalf("expected path %v, got %v", wantPath, first.Path)
+	}
+
+	var errs v.ValidationErrors
+	if !errors.As(set.ValidateAll(), &errs) {
+		t.Fatalf("expected v.ValidationErrors")
+	}
+	// a pipe stops at its first failing action, so only the key errors are reported.
+	if len(errs) != 1 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	err := v.MapPipe(map[string]int{"cpu": 2, "memory": -1}, v.Values[string](v.IsPositive())).ValidateAll()
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Key != `["memory"]` {
+		t.Fatalf("unexpected errors: %v", err)
+	}
+}
+
+func TestMapPipeRequiredAndForbiddenKeys(t *testing.T) {
+	limits := map[string]int{"cpu": 2, "internal": 1}
+
+	err := v.NewPipesBuilder(
+		v.Entry("limits").Pipe(v.MapPipe(limits,
+			v.MinKeys[string, int](1),
+			v.RequiredKeys[string, int]("cpu", "memory", "disk"),
+		)),
+		v.Entry("flags").Pipe(v.MapPipe(limits, v.ForbiddenKeys[string, int]("internal"))),
+	).ValidateAll()
+
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected v.ValidationErrors, got %v", err)
+	}
+	want := []string{`limits["memory"]`, `limits["disk"]`, `flags["internal"]`}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, key := range want {
+		if errs[i].Key != key {
+			t.Errorf("error %d: expected key %s, got %s", i, key, errs[i].Key)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package tests_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type addressSchema struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+func (a *addressSchema) Rules() (v.PipeSet, error) {
+	return v.PipeMap{
+		"city": v.StringPipe(a.City, v.NotEmpty()),
+		"zip":  v.StringPipe(a.Zip, v.MinLength(5)),
+	}, nil
+}
+
+type userSchema struct {
+	Name    string         `json:"name"`
+	Address addressSchema  `json:"address"`
+	Billing *addressSchema `json:"billing"`
+}
+
+func (u *userSchema) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("name").StringPipe(u.Name, v.NotEmpty()),
+		v.Entry("address").Pipe(v.SchemaPipe(&u.Address)),
+		v.Entry("billing").Pipe(v.SchemaPipe(u.Billing)),
+	), nil
+}
+
+func TestSchemaPipeValidateAll(t *testing.T) {
+	err := v.ParseBytesFull([]byte(`{"name":"","address":{"city":"","zip":"12"}}`), &userSchema{})
+
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected v.ValidationErrors, got %v", err)
+	}
+	want := []string{"name", "address.city", "address.zip"}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, key := range want {
+		if errs[i].Key != key {
+			t.Errorf("error %d: expected key %q, got %q", i, key, errs[i].Key)
+		}
+	}
+	wantPath := v.Path{{Key: "address"}, {Key: "city"}}
+	if !reflect.DeepEqual(errs[1].Path, wantPath) {
+		t.Errorf("expected path %v, got %v", wantPath, errs[1].Path)
+	}
+}
+
+func TestSchemaPipeValidateFirst(t *testing.T) {
+	err := v.ParseBytes([]byte(`{"name":"John","address":{"city":"Dhaka","zip":"1"},"billing":{"city":""}}`), &userSchema{})
+
+	var pipeErr *v.PipeError
+	if !errors.As(err, &pipeErr) {
+		t.Fatalf("expected *v.PipeError, got %v", err)
+	}
+	if pipeErr.Key != "address.zip" {
+		t.Fatalf("expected address.zip, got %q", pipeErr.Key)
+	}
+}
+
+func TestObjectPipeInPipeMap(t *testing.T) {
+	set := v.NewPipesMap(v.PipeMap{
+		"user": v.ObjectPipe(v.PipeMap{
+			"profile": v.ObjectPipe(v.PipeMap{
+				"email": v.StringPipe("nope", v.IsEmail()),
+			}),
+		}),
+	})
+
+	var pipeErr *v.PipeError
+	if !errors.As(set.Validate(), &pipeErr) || pipeErr.Key != "user.profile.email" {
+		t.Fatalf("expected user.profile.email, got %v", pipeErr)
+	}
+	if len(pipeErr.Path) != 3 {
+		t.Fatalf("expected 3 path segments, got %v", pipeErr.Path)
+	}
+}
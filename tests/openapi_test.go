@@ -0,0 +1,132 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type openAPILogin struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+func (l *openAPILogin) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("user").StringPipe(l.User, v.NotEmpty()),
+		v.Entry("password").StringPipe(l.Password, v.MinLength(12)),
+	), nil
+}
+
+func TestOpenAPIJSON(t *testing.T) {
+	data, err := v.NewOpenAPI("Accounts API", "1.0.0").
+		Add("Login", &openAPILogin{}).
+		Add("Signup", &jsonSchemaSignup{}).
+		JSON()
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas   map[string]map[string]any `json:"schemas"`
+			Responses map[string]any            `json:"responses"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if doc.OpenAPI != "3.1.0" {
+		t.Fatalf("expected openapi 3.1.0, got %q", doc.OpenAPI)
+	}
+	for _, name := range []string{"Login", "Signup", v.PipeErrorComponent, v.ParseErrorComponent} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Fatalf("expected component %s, got %v", name, doc.Components.Schemas)
+		}
+	}
+	if _, ok := doc.Components.Schemas["Login"]["$schema"]; ok {
+		t.Fatal("expected components without $schema")
+	}
+	if _, ok := doc.Components.Responses["ValidationError"]; !ok {
+		t.Fatalf("expected ValidationError response, got %v", doc.Components.Responses)
+	}
+}
+
+func TestOpenAPIYAML(t *testing.T) {
+	data, err := v.NewOpenAPI("Accounts API", "1.0.0").Add("Login", &openAPILogin{}).YAML()
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	want := `    Login:
+      properties:
+        password:
+          minLength: 12
+          type: string
+        user:
+          minLength: 1
+          type: string
+      required:
+        - user
+      type: object
+`
+	if !strings.Contains(string(data), want) {
+		t.Fatalf("expected Login component:\n%s\ngot:\n%s", want, data)
+	}
+	for _, line := range []string{
+		`            "$ref": "#/components/schemas/ParseError"`,
+		`            - "$ref": "#/components/schemas/PipeError"`,
+		`  version: "1.0.0"`,
+		`openapi: "3.1.0"`,
+	} {
+		if !strings.Contains(string(data), line+"\n") {
+			t.Fatalf("expected line %q in:\n%s", line, data)
+		}
+	}
+}
+
+// TestOpenAPIErrorSchemas checks that the error components declare every
+// field of the JSON written for a ParseError.
+func TestOpenAPIErrorSchemas(t *testing.T) {
+	doc, err := v.NewOpenAPI("Accounts API", "1.0.0").Document()
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+
+	parseErr := v.ParseBytesFull([]byte(`{"user":"","password":"short"}`), &openAPILogin{})
+	data, err := json.Marshal(parseErr)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var body map[string][]map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("expected validation_error list, got %s", data)
+	}
+
+	parseProps := schemas[v.ParseErrorComponent].(v.JSONSchema)["properties"].(v.JSONSchema)
+	pipeProps := schemas[v.PipeErrorComponent].(v.JSONSchema)["properties"].(v.JSONSchema)
+	for field, list := range body {
+		if _, ok := parseProps[field]; !ok {
+			t.Fatalf("ParseError schema misses %q", field)
+		}
+		for _, item := range list {
+			for key := range item {
+				if _, ok := pipeProps[key]; !ok {
+					t.Fatalf("PipeError schema misses %q", key)
+				}
+			}
+		}
+	}
+}
+
+func TestOpenAPIReservedName(t *testing.T) {
+	_, err := v.NewOpenAPI("Accounts API", "1.0.0").Add(v.PipeErrorComponent, &openAPILogin{}).JSON()
+	if err == nil {
+		t.Fatal("expected an error for a reserved component name")
+	}
+}
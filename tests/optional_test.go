@@ -0,0 +1,68 @@
+package tests_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type profileSchema struct {
+	Nickname *string `json:"nickname"`
+	Bio      *string `json:"bio"`
+	Age      *int    `json:"age"`
+}
+
+func (p *profileSchema) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"nickname": v.Optional(p.Nickname, v.MinLength(3)),
+		"bio":      v.Nullable(p.Bio, v.MaxLength(10)),
+		"age":      v.Required(p.Age, v.Min(18)),
+	}), nil
+}
+
+func TestPointerPipesWithParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantKeys []string
+	}{
+		{"all absent", `{}`, []string{"age"}},
+		{"nulls", `{"nickname":null,"bio":null,"age":null}`, []string{"age"}},
+		{"valid", `{"nickname":"neo","bio":"hi","age":30}`, nil},
+		{"invalid values", `{"nickname":"x","bio":"far too long bio","age":3}`, []string{"age", "bio", "nickname"}},
+		{"zero values are present", `{"nickname":"","age":0}`, []string{"age", "nickname"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ParseBytesFull([]byte(tt.data), &profileSchema{})
+			if tt.wantKeys == nil {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+
+			var errs v.ValidationErrors
+			if !errors.As(err, &errs) || len(errs) != len(tt.wantKeys) {
+				t.Fatalf("expected errors on %v, got %v", tt.wantKeys, err)
+			}
+			for i, key := range tt.wantKeys {
+				if errs[i].Key != key {
+					t.Errorf("error %d: expected key %q, got %q", i, key, errs[i].Key)
+				}
+			}
+		})
+	}
+}
+
+func TestRequiredError(t *testing.T) {
+	err := v.NewPipesBuilder(v.Entry("email").Pipe(v.Required[string](nil, v.IsEmail()))).Validate()
+	if !errors.Is(err, v.ErrRequired) {
+		t.Fatalf("expected v.ErrRequired, got %v", err)
+	}
+	if err.Error() != "email: is required" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+}
@@ -0,0 +1,71 @@
+package tests_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func failingPipeMap() v.PipeMap {
+	return v.PipeMap{
+		"zip":      v.StringPipe("", v.NotEmpty()),
+		"email":    v.StringPipe("nope", v.IsEmail()),
+		"age":      v.IntPipe(3, v.Min(18)),
+		"password": v.StringPipe("", v.NotEmpty()),
+		"name":     v.StringPipe("", v.NotEmpty()),
+	}
+}
+
+func errorKeys(t *testing.T, err error) []string {
+	t.Helper()
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected v.ValidationErrors, got %T", err)
+	}
+	keys := make([]string, len(errs))
+	for i, e := range errs {
+		keys[i] = e.Key
+	}
+	return keys
+}
+
+func TestPipeMapIsSorted(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		m := failingPipeMap()
+
+		var first *v.PipeError
+		if !errors.As(m.Validate(), &first) || first.Key != "age" {
+			t.Fatalf("expected first failure on 'age', got %v", first)
+		}
+
+		got := strings.Join(errorKeys(t, m.ValidateAll()), ",")
+		if got != "age,email,name,password,zip" {
+			t.Fatalf("unexpected order: %s", got)
+		}
+	}
+}
+
+func TestNewPipesMapOrdering(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []v.MapOption
+		want    string
+	}{
+		{"sorted", nil, "age,email,name,password,zip"},
+		{"priority", []v.MapOption{v.WithKeyOrder("password", "email", "missing")}, "password,email,age,name,zip"},
+		{"custom sort", []v.MapOption{v.WithKeySort(func(a, b string) int { return strings.Compare(b, a) })}, "zip,password,name,email,age"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				got := strings.Join(errorKeys(t, v.NewPipesMap(failingPipeMap(), tt.options...).ValidateAll()), ",")
+				if got != tt.want {
+					t.Fatalf("expected %s, got %s", tt.want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package tests_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestValidateAllParallelKeepsOrder(t *testing.T) {
+	pipes := make([]v.PipeFace, 0, 100)
+	for i := 0; i < 100; i++ {
+		value := i
+		if i%3 == 0 {
+			value = -i - 1
+		}
+		pipes = append(pipes, v.Entry(fmt.Sprintf("f%d", i)).IntPipe(value, v.Min(0)))
+	}
+	registry := v.NewPipesBuilder(pipes...).(*v.PipeRegistry)
+
+	want := registry.ValidateAll()
+	for _, limit := range []int{1, 4, 16} {
+		got := registry.ValidateAllParallel(v.Concurrency(limit))
+		if got.Error() != want.Error() {
+			t.Fatalf("concurrency %d: parallel errors differ from sequential\n got: %v\nwant: %v", limit, got, want)
+		}
+	}
+}
+
+func TestValidateAllParallelRecoversPanic(t *testing.T) {
+	boom := v.CustomString(func(string) bool { panic("boom") })
+
+	err := v.NewPipesBuilder(
+		v.Entry("ok").StringPipe("hi", v.NotEmpty()),
+		v.Entry("bad").StringPipe("hi", boom),
+	).(*v.PipeRegistry).ValidateAllParallel(v.Concurrency(2))
+
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected one validation error, got %v", err)
+	}
+	if errs[0].Key != "bad" || !errors.Is(errs[0], v.ErrPanic) {
+		t.Fatalf("expected panic error on 'bad', got %v", errs[0])
+	}
+}
+
+type parallelSchema struct {
+	Name string
+	Age  int
+}
+
+func (s *parallelSchema) Rules() (v.PipeSet, error) {
+	return v.PipeMap{
+		"name": v.StringPipe(s.Name, v.NotEmpty()),
+		"age":  v.IntPipe(s.Age, v.Min(18)),
+	}, nil
+}
+
+func TestValidateAllParallelSchema(t *testing.T) {
+	err := v.ValidateAllParallel(&parallelSchema{Name: "", Age: 3}, v.Concurrency(2))
+
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected two validation errors, got %v", err)
+	}
+
+	if err := v.ValidateAllParallel(&parallelSchema{Name: "Jane", Age: 30}); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
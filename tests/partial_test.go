@@ -0,0 +1,86 @@
+package tests_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type accountUpdate struct {
+	Name    string       `json:"name"`
+	Email   string       `json:"email"`
+	Age     int          `json:"age"`
+	Version int          `json:"version"`
+	Address patchAddress `json:"address"`
+}
+
+func (a *accountUpdate) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"name":    v.StringPipe(a.Name, v.NotEmpty()),
+		"email":   v.StringPipe(a.Email, v.IsEmail()),
+		"age":     v.IntPipe(a.Age, v.NonZero()),
+		"version": v.Always(v.IntPipe(a.Version, v.IsPositive())),
+		"address": v.ObjectPipe(v.PipeMap{
+			"city": v.StringPipe(a.Address.City, v.NotEmpty()),
+			"zip":  v.StringPipe("", v.NotEmpty()),
+		}),
+	}), nil
+}
+
+func partialErrorKeys(err error) string {
+	if err == nil {
+		return ""
+	}
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) {
+		return "not ValidationErrors: " + err.Error()
+	}
+	keys := make([]string, len(errs))
+	for i, e := range errs {
+		keys[i] = e.Key
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestParseBytesPartialFull(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"only name", `{"name":"Jane","version":1}`, ""},
+		{"empty name", `{"name":"","version":1}`, "name"},
+		{"always runs", `{"email":"jane@example.com"}`, "version"},
+		{"nested partial", `{"version":2,"address":{"city":""}}`, "address.city"},
+		{"full update", `{"name":"","email":"x","age":0,"version":0,"address":{"city":"","zip":""}}`, "address.city,address.zip,age,email,name,version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ParseBytesPartialFull([]byte(tt.data), &accountUpdate{})
+			var parseErr *v.ParseError
+			if err != nil && !errors.As(err, &parseErr) {
+				t.Fatalf("expected *v.ParseError, got %T", err)
+			}
+			if got := partialErrorKeys(err); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidatePartial(t *testing.T) {
+	update := &accountUpdate{Version: 1, Email: "nope"}
+
+	if err := v.ValidatePartial(update, v.NewPresence("name")); err == nil || !strings.HasPrefix(err.Error(), "name:") {
+		t.Fatalf("expected name error, got %v", err)
+	}
+	if err := v.ValidatePartial(update, nil); err != nil {
+		t.Fatalf("expected nil with nothing present, got %v", err)
+	}
+	if got := partialErrorKeys(v.ValidateAllPartial(update, v.NewPresence("email", "age"))); got != "age,email" {
+		t.Fatalf("expected age,email, got %q", got)
+	}
+}
@@ -0,0 +1,91 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestPathRenderers(t *testing.T) {
+	cases := []struct {
+		path                  v.Path
+		dotted, pointer, form string
+	}{
+		{v.Path{v.PathKey("items"), v.PathIndex(3), v.PathKey("sku")}, "items[3].sku", "/items/3/sku", "items.3.sku"},
+		{v.Path{v.PathKey("metadata"), v.PathMapKey("a/b~c")}, `metadata["a/b~c"]`, "/metadata/a~1b~0c", "metadata.a/b~c"},
+		{v.Path{v.PathIndex(0)}, "[0]", "/0", "0"},
+		{nil, "", "", ""},
+	}
+	for _, c := range cases {
+		if got := c.path.String(); got != c.dotted {
+			t.Fatalf("expected dotted %q, got %q", c.dotted, got)
+		}
+		if got := c.path.Pointer(); got != c.pointer {
+			t.Fatalf("expected pointer %q, got %q", c.pointer, got)
+		}
+		if got := c.path.Form(); got != c.form {
+			t.Fatalf("expected form %q, got %q", c.form, got)
+		}
+	}
+}
+
+type decodeLine struct {
+	Qty int `json:"qty"`
+}
+
+type decodeOrder struct {
+	v.Include
+	Lines  []decodeLine          `json:"lines"`
+	Extras map[string]decodeLine `json:"extras"`
+	Limits map[string]int        `json:"limits"`
+	Note   *struct{ Pages int }  `json:"note"`
+}
+
+func (o *decodeOrder) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(), nil
+}
+
+func TestParseDecodeErrorPath(t *testing.T) {
+	cases := map[string]string{
+		`{"lines":[{"qty":1},{"qty":"2"}]}`: "/lines/1/qty",
+		`{"extras":{"a.b":{"qty":"x"}}}`:    "/extras/a.b/qty",
+		`{"limits":{"x.y":"1"}}`:            "/limits/x.y",
+		`{"note":{"Pages":"1"}}`:            "/note/Pages",
+		`{"lines":"none"}`:                  "/lines",
+	}
+	for payload, want := range cases {
+		err := v.ParseBytes([]byte(payload), &decodeOrder{})
+
+		var decodeErr *v.DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Fatalf("%s: expected a DecodeError, got %v", payload, err)
+		}
+		if got := decodeErr.Path.Pointer(); got != want {
+			t.Fatalf("%s: expected %s, got %s", payload, want, got)
+		}
+
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Fatalf("%s: expected the json error in the chain", payload)
+		}
+	}
+
+	if _, ok := v.DecodeErrorPath(v.ParseBytes([]byte(`{"lines":`), &decodeOrder{}), &decodeOrder{}); ok {
+		t.Fatal("expected no path for a syntax error")
+	}
+}
+
+func TestProblemDecodeError(t *testing.T) {
+	problem := v.NewProblem(v.ParseBytes([]byte(`{"lines":[{"qty":"2"}]}`), &decodeOrder{}))
+	if problem.Status != 400 || len(problem.InvalidParams) != 1 {
+		t.Fatalf("unexpected problem %+v", problem)
+	}
+	if param := problem.InvalidParams[0]; param.Name != "lines[0].qty" || param.Pointer != "/lines/0/qty" || param.Reason != "must be of type integer" {
+		t.Fatalf("unexpected param %+v", param)
+	}
+	if problem.Detail != "request body could not be decoded" {
+		t.Fatalf("expected a detail without Go type names, got %q", problem.Detail)
+	}
+}
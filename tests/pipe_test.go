@@ -0,0 +1,89 @@
+package tests_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type money struct {
+	Amount   int
+	Currency string
+}
+
+func validCurrency(option ...v.ActionOptionFace) v.Action[money] {
+	return v.NewAction("unsupported currency", func(m money) bool {
+		return m.Currency == "EUR" || m.Currency == "USD"
+	}, option...)
+}
+
+func TestGenericPipeWithDomainAction(t *testing.T) {
+	err := v.NewPipe(money{Amount: 10, Currency: "EUR"}, validCurrency()).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = v.NewPipesBuilder(
+		v.Entry("price").Pipe(v.NewPipe(money{Amount: 10, Currency: "JPY"}, validCurrency(v.ErrMsg("currency not accepted")))),
+	).Validate()
+
+	var pipeErr *v.PipeError
+	if !errors.As(err, &pipeErr) {
+		t.Fatalf("expected *v.PipeError, got %T", err)
+	}
+	if pipeErr.Key != "price" {
+		t.Errorf("expected key 'price', got %q", pipeErr.Key)
+	}
+	if pipeErr.Err.Error() != "currency not accepted" {
+		t.Errorf("unexpected message: %q", pipeErr.Err.Error())
+	}
+}
+
+func TestActionFunc(t *testing.T) {
+	reserved := errors.New("reserved name")
+	notAdmin := v.ActionFunc[string](func(s string) error {
+		if s == "admin" {
+			return reserved
+		}
+		return nil
+	})
+
+	if err := v.StringPipe("john", v.NotEmpty(), notAdmin).Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.StringPipe("admin", v.NotEmpty(), notAdmin).Validate(); !errors.Is(err, reserved) {
+		t.Fatalf("expected reserved error, got %v", err)
+	}
+}
+
+func TestEntrySetsKey(t *testing.T) {
+	err := v.NewPipesBuilder(
+		v.Entry("name").StringPipe("", v.NotEmpty()),
+		v.Entry("age").IntPipe(0, v.NonZero()),
+		v.Entry("score").FloatPipe(-1, v.IsPositiveFloat()),
+		v.Entry("at").TimePipe(time.Time{}, v.NotEmptyDate()),
+	).ValidateAll()
+
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected v.ValidationErrors, got %T", err)
+	}
+	want := []string{"name", "age", "score", "at"}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d", len(want), len(errs))
+	}
+	for i, key := range want {
+		if errs[i].Key != key {
+			t.Errorf("error %d: expected key %q, got %q", i, key, errs[i].Key)
+		}
+	}
+}
+
+func TestIntPipeManagerAlias(t *testing.T) {
+	var pipe *v.IntPipeManager = v.IntPipe(5, v.Min(10))
+	if err := pipe.Validate(); err == nil {
+		t.Fatal("expected an error for 5 < 10")
+	}
+}
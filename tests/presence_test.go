@@ -0,0 +1,190 @@
+package tests_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type patchAddress struct {
+	City string `json:"city"`
+}
+
+func (a *patchAddress) Rules() (v.PipeSet, error) {
+	return v.PipeMap{
+		"city": v.RequirePresent(v.StringPipe(a.City)),
+	}, nil
+}
+
+type patchUser struct {
+	v.Include
+	Name    string       `json:"name"`
+	Age     int          `json:"age"`
+	Bio     *string      `json:"bio"`
+	Address patchAddress `json:"address"`
+	Tags    []string     `json:"tags"`
+}
+
+func (u *patchUser) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"name":    v.IfPresent(v.StringPipe(u.Name, v.NotEmpty())),
+		"age":     v.RequirePresent(v.IntPipe(u.Age, v.Max(150))),
+		"bio":     v.Nullable(u.Bio, v.MaxLength(20)),
+		"address": v.IfPresent(v.SchemaPipe(&u.Address)),
+	}), nil
+}
+
+func TestParseRecordsPresence(t *testing.T) {
+	var u patchUser
+	err := v.Parse(strings.NewReader(`{"age":0,"bio":null,"address":{"city":"Dhaka"},"tags":["a","b"]}`), &u)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	got := strings.Join(u.Presence().Paths(), ",")
+	if got != "address,address.city,age,bio,tags,tags[0],tags[1]" {
+		t.Fatalf("unexpected presence: %s", got)
+	}
+	if !u.Present("age") || u.Present("name") {
+		t.Fatalf("expected age present and name absent")
+	}
+}
+
+func TestPresenceAwarePipes(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantKeys []string
+	}{
+		{"zero age sent", `{"age":0,"bio":null}`, nil},
+		{"nothing sent", `{}`, []string{"age", "bio"}},
+		{"empty name sent", `{"name":"","age":1,"bio":"hi"}`, []string{"name"}},
+		{"nested absent", `{"age":1,"bio":null,"address":{}}`, []string{"address.city"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ParseFull(bytes.NewReader([]byte(tt.data)), &patchUser{})
+			if tt.wantKeys == nil {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+
+			var errs v.ValidationErrors
+			if !errors.As(err, &errs) || len(errs) != len(tt.wantKeys) {
+				t.Fatalf("expected errors on %v, got %v", tt.wantKeys, err)
+			}
+			for i, key := range tt.wantKeys {
+				if errs[i].Key != key {
+					t.Errorf("error %d: expected %q, got %v", i, key, errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPresenceUnknownRunsPipes(t *testing.T) {
+	// without a Parse helper presence is unknown, so every pipe runs.
+	err := v.ValidateAll(&patchUser{Name: ""})
+
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Key != "name" {
+		t.Fatalf("expected a single name error, got %v", err)
+	}
+}
+
+func TestValidateAllParallelUsesPresence(t *testing.T) {
+	var u patchUser
+	if err := v.ParseBytes([]byte(`{"name":""}`), &u); err == nil {
+		t.Fatal("expected a parse error")
+	}
+
+	want := v.ValidateAll(&u)
+	got := v.ValidateAllParallel(&u, v.Concurrency(2))
+	if got == nil || got.Error() != want.Error() {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	var errs v.ValidationErrors
+	if !errors.As(got, &errs) || len(errs) != 3 || errs[1].Key != "bio" || !errors.Is(errs[1], v.ErrRequired) {
+		t.Fatalf("expected age, a required bio and name, got %v", got)
+	}
+}
+
+type lazyPresenceUser struct {
+	v.Include
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+	// track selects rules that look at presence.
+	track bool
+}
+
+func (u *lazyPresenceUser) Rules() (v.PipeSet, error) {
+	var name v.PipeFace = v.StringPipe(u.Name, v.NotEmpty())
+	if u.track {
+		name = v.RequirePresent(name)
+	}
+	return v.PipeMap{"name": name}, nil
+}
+
+func TestParseRecordsPresenceOnlyWhenAsked(t *testing.T) {
+	data := []byte(`{"name":"Jane","tags":["a","b","c","d","e","f","g","h","i","j","k","l"]}`)
+	allocs := func(track bool) float64 {
+		return testing.AllocsPerRun(20, func() {
+			if err := v.ParseBytes(data, &lazyPresenceUser{track: track}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// walking the payload for presence costs more allocations than the
+	// rest of the parse, so it must be skipped when nothing asks for it.
+	plain, tracked := allocs(false), allocs(true)
+	if plain*2 > tracked {
+		t.Fatalf("expected rules without presence to skip recording it, got %v allocs vs %v", plain, tracked)
+	}
+}
+
+type comparableUser struct {
+	v.Include
+	Name string `json:"name"`
+}
+
+func TestIncludeKeepsSchemasComparable(t *testing.T) {
+	a, b := comparableUser{Name: "Jane"}, comparableUser{Name: "Jane"}
+	if a != b {
+		t.Fatalf("expected equal schemas")
+	}
+	seen := map[comparableUser]bool{a: true}
+	if !seen[b] {
+		t.Fatalf("expected the schema to work as a map key")
+	}
+}
+
+func TestParseReaderRecordsFirstValue(t *testing.T) {
+	var u patchUser
+	err := v.Parse(strings.NewReader(`{"age":1,"bio":null} {"name":"next"}`), &u)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got := strings.Join(u.Presence().Paths(), ","); got != "age,bio" {
+		t.Fatalf("expected only the paths of the first value, got %s", got)
+	}
+}
+
+func TestValidateWithoutPresenceDoesNotAllocate(t *testing.T) {
+	set := v.NewPipesBuilder(
+		v.Entry("age").IntPipe(30, v.Min(18), v.Max(150)),
+		v.Entry("name").StringPipe("Jane", v.NotEmpty(), v.MaxLength(20)),
+	)
+
+	// the run settings only need the heap once presence or partial runs are in use
+	if allocs := testing.AllocsPerRun(20, func() { _ = set.ValidateAll() }); allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
@@ -0,0 +1,101 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type problemItem struct {
+	SKU string `json:"sku"`
+}
+
+type problemOrder struct {
+	Email string        `json:"email"`
+	Items []problemItem `json:"items"`
+}
+
+func (o *problemOrder) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("email").StringPipe(o.Email, v.IsEmail()),
+		v.Entry("items").Pipe(v.SlicePipe(o.Items, v.EachPipe(func(item problemItem) v.PipeFace {
+			return v.SchemaPipe(&item)
+		}))),
+	), nil
+}
+
+func (i *problemItem) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("sku").StringPipe(i.SKU, v.MinLength(3, v.ErrMsg("sku {VALUE} is too short"))),
+	), nil
+}
+
+func TestWriteProblem(t *testing.T) {
+	err := v.ParseBytesFull([]byte(`{"email":"nope","items":[{"sku":"abc"},{"sku":"x"}]}`), &problemOrder{})
+
+	rec := httptest.NewRecorder()
+	if writeErr := v.WriteProblem(rec, err,
+		v.ProblemType("https://example.com/problems/validation"),
+		v.ProblemTitle("Your request is not valid."),
+		v.ProblemInstance("/orders"),
+	); writeErr != nil {
+		t.Fatalf("expected nil, got %v", writeErr)
+	}
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != v.ProblemContentType {
+		t.Fatalf("expected %s, got %s", v.ProblemContentType, ct)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := map[string]any{
+		"type":     "https://example.com/problems/validation",
+		"title":    "Your request is not valid.",
+		"status":   422.0,
+		"instance": "/orders",
+		"invalid-params": []any{
+			map[string]any{"name": "email", "pointer": "/email", "code": "string.email", "reason": "not a valid email"},
+			map[string]any{"name": "items[1].sku", "pointer": "/items/1/sku", "code": "string.min_length", "reason": "sku x is too short"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected problem:\n%s", rec.Body.String())
+	}
+}
+
+func TestNewProblemStatus(t *testing.T) {
+	parseErr := v.ParseBytes([]byte(`{"email":`), &problemOrder{})
+	problem := v.NewProblem(parseErr)
+	if problem.Status != http.StatusBadRequest || problem.Type != "about:blank" || problem.Title != "Bad Request" {
+		t.Fatalf("unexpected problem %+v", problem)
+	}
+	if problem.Detail == "" || len(problem.InvalidParams) != 0 {
+		t.Fatalf("expected a detail without params, got %+v", problem)
+	}
+
+	problem = v.NewProblem(v.Validate(&problemOrder{Email: "nope"}), v.ProblemStatus(http.StatusBadRequest))
+	if problem.Status != http.StatusBadRequest || len(problem.InvalidParams) != 1 {
+		t.Fatalf("unexpected problem %+v", problem)
+	}
+
+	problem = v.NewProblem(v.ValidateAll(&failingRules{}))
+	if problem.Status != http.StatusInternalServerError || len(problem.InvalidParams) != 0 {
+		t.Fatalf("expected a 500 without params, got %+v", problem)
+	}
+}
+
+type failingRules struct{}
+
+func (*failingRules) Rules() (v.PipeSet, error) {
+	return nil, errors.New("rules unavailable")
+}
@@ -0,0 +1,90 @@
+package tests_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestSlicePipeCollectionActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		actions []v.SliceAction[string]
+		wantErr bool
+	}{
+		{"min ok", []string{"a"}, []v.SliceAction[string]{v.MinItems[string](1)}, false},
+		{"min fail", nil, []v.SliceAction[string]{v.MinItems[string](1)}, true},
+		{"max fail", []string{"a", "b", "c"}, []v.SliceAction[string]{v.MaxItems[string](2)}, true},
+		{"unique ok", []string{"a", "b"}, []v.SliceAction[string]{v.UniqueItems[string]()}, false},
+		{"unique fail", []string{"a", "b", "a"}, []v.SliceAction[string]{v.UniqueItems[string]()}, true},
+		{"contains ok", []string{"a", "b"}, []v.SliceAction[string]{v.ContainsItem("b")}, false},
+		{"contains fail", []string{"a"}, []v.SliceAction[string]{v.ContainsItem("b")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.SlicePipe(tt.values, tt.actions...).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wantErr %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSlicePipeEachIndexedKeys(t *testing.T) {
+	recipients := []string{"a@example.com", "nope", "b@example.com", ""}
+
+	set := v.NewPipesMap(v.PipeMap{
+		"recipients": v.SlicePipe(recipients, v.MinItems[string](1), v.Each(v.NotEmpty(), v.IsEmail())),
+	})
+
+	var first *v.PipeError
+	if !errors.As(set.Validate(), &first) || first.Key != "recipients[1]" {
+		t.Fatalf("expected recipients[1], got %v", first)
+	}
+
+	var errs v.ValidationErrors
+	if !errors.As(set.ValidateAll(), &errs) {
+		t.Fatalf("expected v.ValidationErrors")
+	}
+	if len(errs) != 2 || errs[0].Key != "recipients[1]" || errs[1].Key != "recipients[3]" {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	wantPath := v.Path{v.PathKey("recipients"), v.PathIndex(3)}
+	if !reflect.DeepEqual(errs[1].Path, wantPath) {
+		t.Fatalf("expected path %v, got %v", wantPath, errs[1].Path)
+	}
+}
+
+type lineItem struct {
+	SKU string
+	Qty int
+}
+
+func (l *lineItem) Rules() (v.PipeSet, error) {
+	return v.PipeMap{
+		"sku": v.StringPipe(l.SKU, v.NotEmpty()),
+		"qty": v.IntPipe(l.Qty, v.IsPositive()),
+	}, nil
+}
+
+func TestSlicePipeEachSchema(t *testing.T) {
+	items := []lineItem{{SKU: "A1", Qty: 1}, {SKU: "", Qty: 0}}
+
+	err := v.NewPipesBuilder(
+		v.Entry("items").Pipe(v.SlicePipe(items, v.EachPipe(func(item lineItem) v.PipeFace {
+			return v.SchemaPipe(&item)
+		}))),
+	).ValidateAll()
+
+	var errs v.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected v.ValidationErrors, got %v", err)
+	}
+	if len(errs) != 2 || errs[0].Key != "items[1].qty" || errs[1].Key != "items[1].sku" {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
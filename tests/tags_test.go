@@ -0,0 +1,178 @@
+package tests_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type tagUser struct {
+	v.Include
+	Name     string   `json:"name" valgo:"required,trim,max=20"`
+	Email    string   `json:"email" valgo:"required,email,max=40"`
+	Age      int      `json:"age" valgo:"min=18,max=130"`
+	Score    float64  `json:"score" valgo:"gte=0,lte=1"`
+	Role     string   `json:"role" valgo:"oneof=admin editor viewer"`
+	Code     string   `json:"code" valgo:"pattern=^[A-Z]{2,3}$"`
+	Nickname *string  `json:"nickname" valgo:"min=3"`
+	Referrer *string  `json:"referrer" valgo:"required"`
+	Level    uint8    `json:"level" valgo:"max=5"`
+	Internal string   `json:"-" valgo:"-"`
+	Tags     []string `json:"tags"`
+}
+
+func TestTagRulesWithParse(t *testing.T) {
+	var user tagUser
+	data := `{"name":"  Jane  ","email":"jane@example.com","age":30,"score":0.5,"role":"admin","code":"AB","referrer":"x"}`
+	if err := v.ParseBytesFull([]byte(data), &user); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if user.Name != "Jane" {
+		t.Fatalf("expected the trimmed name to be written back, got %q", user.Name)
+	}
+
+	data = `{"name":"","email":"nope","age":12,"score":2,"role":"root","code":"abcd","nickname":"ab","level":9}`
+	err := v.ParseBytesFull([]byte(data), &tagUser{})
+	var parseErr *v.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	want := "name,email,age,score,role,code,nickname,referrer,level"
+	if got := partialErrorKeys(parseErr.ValidationError); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestTagRulesWithValidate(t *testing.T) {
+	user := &tagUser{Name: "Jane", Email: "bad", Age: 20}
+	err := v.Validate(user)
+	var pipeErr *v.PipeError
+	if !errors.As(err, &pipeErr) || pipeErr.Key != "email" {
+		t.Fatalf("expected the first error on email, got %v", err)
+	}
+
+	referrer := "friend"
+	user = &tagUser{Name: "Jane", Email: "jane@example.com", Age: 20, Role: "viewer", Code: "BD", Referrer: &referrer}
+	if err := v.ValidateAll(user); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+type tagSignup struct {
+	v.Include
+	Password        string `json:"password" valgo:"required,min=8"`
+	PasswordConfirm string `json:"password_confirm"`
+	Country         string `json:"country" valgo:"required"`
+	State           string `json:"state"`
+}
+
+func (s *tagSignup) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"password_confirm": v.StringPipe(s.PasswordConfirm),
+		"password":         v.StringPipe(s.Password),
+		"state":            v.When(v.FieldEquals("password", "override"), v.StringPipe(s.State, v.NotEmpty())),
+	}, v.WithRules(v.EqualField("password_confirm", "password"))), nil
+}
+
+func TestTagRulesCoexistWithRules(t *testing.T) {
+	err := v.ParseBytesFull([]byte(`{"password":"short","password_confirm":"other"}`), &tagSignup{})
+	var parseErr *v.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	if got := partialErrorKeys(parseErr.ValidationError); got != "password,country,password_confirm" {
+		t.Fatalf("expected tag errors followed by rule errors, got %q", got)
+	}
+
+	if err := v.ValidateAll(&tagSignup{Password: "longenough", PasswordConfirm: "longenough", Country: "BD"}); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestTagRulesPartial(t *testing.T) {
+	err := v.ParseBytesPartialFull([]byte(`{"email":"nope"}`), &tagUser{})
+	var parseErr *v.ParseError
+	if !errors.As(err, &parseErr) || partialErrorKeys(parseErr.ValidationError) != "email" {
+		t.Fatalf("expected only email to be validated, got %v", err)
+	}
+}
+
+type badTagRule struct {
+	v.Include
+	Age int `json:"age" valgo:"email"`
+}
+
+type unsupportedTagType struct {
+	v.Include
+	Tags []string `json:"tags" valgo:"required"`
+}
+
+func TestTagRulesReportCompileErrors(t *testing.T) {
+	err := v.ValidateAll(&badTagRule{})
+	if err == nil || !strings.Contains(err.Error(), `valgo: badTagRule.Age: unknown rule "email"`) {
+		t.Fatalf("expected unknown rule error, got %v", err)
+	}
+
+	err = v.ParseBytes([]byte(`{}`), &unsupportedTagType{})
+	var parseErr *v.ParseError
+	if !errors.As(err, &parseErr) || parseErr.PreError == nil || !strings.Contains(err.Error(), "unsupported type []string") {
+		t.Fatalf("expected a pre error, got %v", err)
+	}
+}
+
+type trimmedPointer struct {
+	v.Include
+	Name *string `json:"name" valgo:"trim"`
+}
+
+func TestTagRulesRejectTransformsOnPointers(t *testing.T) {
+	name := " Jane "
+	err := v.ValidateAll(&trimmedPointer{Name: &name})
+	if err == nil || !strings.Contains(err.Error(), "transform rules don't apply to pointer fields") {
+		t.Fatalf("expected a transform rule error, got %v", err)
+	}
+}
+
+type tagCounter struct {
+	v.Include
+	Count uint64 `json:"count" valgo:"max=5"`
+}
+
+func TestTagRulesRangeCheckUnsignedFields(t *testing.T) {
+	err := v.ValidateAll(&tagCounter{Count: math.MaxUint64})
+	var actionErr *v.ActionError
+	if !errors.As(err, &actionErr) || actionErr.Code != "int.range" {
+		t.Fatalf("expected an int.range error, got %v", err)
+	}
+
+	if err := v.ValidateAll(&tagCounter{Count: 5}); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+type tagEvent struct {
+	v.Include
+	At genStamp `json:"at" valgo:"required,past"`
+}
+
+func TestTagRulesNamedTimeType(t *testing.T) {
+	past := genStamp(time.Now().Add(-time.Hour))
+	if err := v.ValidateAll(&tagEvent{At: past}); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	future := genStamp(time.Now().Add(time.Hour))
+	var pipeErr *v.PipeError
+	if err := v.Validate(&tagEvent{At: future}); !errors.As(err, &pipeErr) || pipeErr.Key != "at" {
+		t.Fatalf("expected an error on at, got %v", err)
+	}
+
+	// valgen generates the same rules for the type
+	if err := v.Validate(&genEvent{Name: "launch", At: future}); !errors.As(err, &pipeErr) || pipeErr.Key != "at" {
+		t.Fatalf("expected the generated rules to fail on at, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestErrMsgTemplates(t *testing.T) {
+	born := time.Date(2030, 5, 17, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"value and param", v.MaxLength(5, v.ErrMsg("{VALUE} is longer than {PARAM.max}")).Run("toolong"), "toolong is longer than 5"},
+		{"len", v.MaxLength(5, v.ErrMsg("{LEN} characters, max {PARAM.max}")).Run("ভাষাভাষা"), "8 characters, max 5"},
+		{"truncate", v.IsEmail(v.ErrMsg("{VALUE|truncate:6} is not an email")).Run("averylongname"), "averyl… is not an email"},
+		{"mask", v.MinLength(12, v.ErrMsg("password {VALUE|mask} is too short")).Run("hunter2"), "password ******** is too short"},
+		{"mask last", v.IsCreditCard(v.ErrMsg("card {VALUE|mask:4} is invalid")).Run("4111111111111112"), "card ************1112 is invalid"},
+		{"date", v.BeforeNow(v.ErrMsg("{VALUE|date:2006-01-02} is in the future")).Run(born), "2030-05-17 is in the future"},
+		{"time default", v.BeforeNow(v.ErrMsg("{VALUE} is in the future")).Run(born), "2030-05-17T10:00:00Z is in the future"},
+		{"unknown", v.IsEmail(v.ErrMsg("{NOPE} {VALUE|shout}")).Run("x"), "{NOPE} {VALUE|shout}"},
+	}
+	for _, c := range cases {
+		if c.err == nil || c.err.Error() != c.want {
+			t.Fatalf("%s: expected %q, got %v", c.name, c.want, c.err)
+		}
+	}
+}
+
+func TestErrMsgKey(t *testing.T) {
+	err := v.NewPipesBuilder(
+		v.Entry("email").StringPipe("x", v.IsEmail(v.ErrMsg("{KEY} must be an email, got {VALUE}"))),
+	).ValidateAll()
+
+	if err == nil || err.Error() != "email: email must be an email, got x" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	data, _ := json.Marshal(err)
+	want := `[{"code":"string.email","key":"email","msg":"email must be an email, got x"}]`
+	if string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+}
+
+func TestCatalogTemplates(t *testing.T) {
+	catalog := v.NewCatalog("en", map[string]string{
+		"string.max_length": "{KEY}: {VALUE|truncate:3} has {LEN} characters, max {PARAM.max}",
+	})
+	en := v.NewTranslator("en", catalog).Locale("en")
+
+	err := en.Localize(v.NewPipesBuilder(v.Entry("bio").StringPipe("abcdef", v.MaxLength(4))).Validate())
+	if err == nil || err.Error() != "bio: bio: abc… has 6 characters, max 4" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestTemplatesRenderOnce(t *testing.T) {
+	// placeholders in the rejected value are text, not part of the template
+	value := "{KEY}{PARAM.max}"
+	err := v.NewPipesBuilder(
+		v.Entry("bio").StringPipe(value, v.MaxLength(3, v.ErrMsg("{VALUE} too long"))),
+		v.Entry("title").StringPipe(value, v.WithMsg(v.MaxLength(3), v.ErrMsg("{KEY}: {VALUE} is over {PARAM.max}"))),
+	).ValidateAll()
+
+	want := "bio: {KEY}{PARAM.max} too long, title: title: {KEY}{PARAM.max} is over 3"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+
+	catalog := v.NewCatalog("en", map[string]string{"string.max_length": "{VALUE} is too long"})
+	err = v.NewTranslator("en", catalog).Locale("en").Localize(
+		v.NewPipesBuilder(v.Entry("bio").StringPipe(value, v.MaxLength(3))).Validate(),
+	)
+	if err == nil || err.Error() != "bio: {KEY}{PARAM.max} is too long" {
+		t.Fatalf("expected the localized value to be left as it is, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package tests_test
+
+import (
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+func TestStringTransforms(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		action v.StringPipeAction
+		want   string
+	}{
+		{"trim", "  jane  ", v.Trim(), "jane"},
+		{"lower", "JaNe", v.ToLower(), "jane"},
+		{"upper", "JaNe", v.ToUpper(), "JANE"},
+		{"normalize space", "  jane \t\n doe ", v.NormalizeSpace(), "jane doe"},
+		{"nfc", "Café", v.NormalizeNFC(), "Café"},
+		{"nfc stacked marks", "ǖ", v.NormalizeNFC(), "ǖ"},
+		{"nfc hangul", "한", v.NormalizeNFC(), "한"},
+		{"nfc singleton", "\u212b", v.NormalizeNFC(), "\u00c5"},
+		{"nfc reorders marks", "a\u0302\u0323", v.NormalizeNFC(), "\u1ead"},
+		{"nfc recomposes", "\u1ea1\u0302", v.NormalizeNFC(), "\u1ead"},
+		{"nfc blocked mark", "a\u0301\u0301", v.NormalizeNFC(), "\u00e1\u0301"},
+		{"nfc exclusion", "\u0958", v.NormalizeNFC(), "\u0915\u093c"},
+		{"nfc hangul jamo", "\u1112\u1161\u11ab", v.NormalizeNFC(), "\ud55c"},
+		{"strip tags", "<b>hi</b> <a href=\"x\">there</a>", v.StripTags(), "hi there"},
+		{"truncate", "ঢাকা শহর", v.Truncate(4), "ঢাকা"},
+		{"truncate short", "abc", v.Truncate(10), "abc"},
+		{"truncate negative", "abc", v.Truncate(-1), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.StringPipe(tt.input, tt.action).Value(); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNumberTransforms(t *testing.T) {
+	if got := v.IntPipe(150, v.Clamp(0, 100)).Value(); got != 100 {
+		t.Fatalf("expected 100, got %d", got)
+	}
+	if got := v.IntPipe(-5, v.Clamp(0, 100)).Value(); got != 0 {
+		t.Fatalf("expected 0, got %d", got)
+	}
+	if got := v.FloatPipe(1.5, v.ClampFloat(0, 1)).Value(); got != 1 {
+		t.Fatalf("expected 1, got %v", got)
+	}
+	if got := v.FloatPipe(3.14159, v.Round(2)).Value(); got != 3.14 {
+		t.Fatalf("expected 3.14, got %v", got)
+	}
+}
+
+func TestTransformFeedsFollowingActions(t *testing.T) {
+	if err := v.StringPipe("  a@b.co  ", v.Trim(), v.IsEmail()).Validate(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	// actions before a transform see the original value
+	if err := v.StringPipe("  abc  ", v.MaxLength(3), v.Trim()).Validate(); err == nil {
+		t.Fatal("expected an error for the untrimmed value")
+	}
+}
+
+type bindSchema struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+func (p *bindSchema) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"name":  v.StringPipe(p.Name, v.NormalizeSpace(), v.NotEmpty()).Bind(&p.Name),
+		"email": v.StringPipe(p.Email, v.Trim(), v.ToLower(), v.IsEmail()).Bind(&p.Email),
+		"age":   v.IntPipe(p.Age, v.Clamp(0, 120)).Bind(&p.Age),
+	}), nil
+}
+
+func TestBindWritesBackAfterParse(t *testing.T) {
+	var profile bindSchema
+	err := v.ParseBytes([]byte(`{"name":"  Jane   Doe ","email":" Jane@Example.COM ","age":300}`), &profile)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	if profile.Name != "Jane Doe" || profile.Email != "jane@example.com" || profile.Age != 120 {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+}
+
+func TestBindSkipsFailingPipe(t *testing.T) {
+	var profile bindSchema
+	err := v.ParseBytes([]byte(`{"name":"Jane","email":" not-an-email "}`), &profile)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if profile.Email != " not-an-email " {
+		t.Fatalf("expected the email to stay untouched, got %q", profile.Email)
+	}
+}
+
+func TestTransformsSeenByCrossFieldRules(t *testing.T) {
+	err := v.NewPipesBuilder(
+		v.Entry("email").StringPipe("A@B.CO", v.ToLower()),
+		v.Entry("email_confirm").StringPipe(" a@b.co ", v.Trim()),
+		v.EqualField("email_confirm", "email"),
+	).ValidateAll()
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
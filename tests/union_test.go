@@ -0,0 +1,109 @@
+package tests_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+type cardPayment struct {
+	v.Include
+	Type   string `json:"type"`
+	Number string `json:"number"`
+	CVC    string `json:"cvc"`
+}
+
+func (c *cardPayment) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"number": v.StringPipe(c.Number, v.IsCreditCard()),
+		"cvc":    v.StringPipe(c.CVC, v.MinLength(3), v.MaxLength(4)),
+	}), nil
+}
+
+type bankPayment struct {
+	Type string `json:"type"`
+	IBAN string `json:"iban"`
+}
+
+func (b *bankPayment) Rules() (v.PipeSet, error) {
+	return v.NewPipesMap(v.PipeMap{
+		"iban": v.StringPipe(b.IBAN, v.NotEmpty()),
+	}), nil
+}
+
+func newPaymentUnion() *v.Union {
+	return v.NewUnion("type").
+		Register("card", func() v.Schema { return &cardPayment{} }).
+		Register("bank", func() v.Schema { return &bankPayment{} })
+}
+
+func TestUnionPicksVariant(t *testing.T) {
+	payments := newPaymentUnion()
+
+	payment, err := payments.ParseBytes([]byte(`{"type":"bank","iban":"DE89370400440532013000"}`))
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	bank, ok := payment.(*bankPayment)
+	if !ok || bank.IBAN != "DE89370400440532013000" {
+		t.Fatalf("expected a decoded bank payment, got %#v", payment)
+	}
+
+	payment, err = payments.ParseFull(strings.NewReader(`{"type":"card","number":"1","cvc":"1"}`))
+	if _, ok := payment.(*cardPayment); !ok {
+		t.Fatalf("expected a card payment, got %#v", payment)
+	}
+	var parseErr *v.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	if got := partialErrorKeys(parseErr.ValidationError); got != "cvc,number" {
+		t.Fatalf("expected cvc,number, got %q", got)
+	}
+}
+
+func TestUnionDiscriminatorErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"unknown", `{"type":"crypto"}`, `type: unknown variant "crypto", expected one of: bank, card`},
+		{"missing", `{"iban":"x"}`, "type: is required"},
+		{"null", `{"type":null,"iban":"x"}`, "type: is required"},
+		{"not a string", `{"type":1}`, "type: must be a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment, err := newPaymentUnion().ParseBytesFull([]byte(tt.data))
+			if payment != nil {
+				t.Fatalf("expected no schema, got %#v", payment)
+			}
+
+			var parseErr *v.ParseError
+			if !errors.As(err, &parseErr) || parseErr.ValidationError == nil {
+				t.Fatalf("expected ParseError with a validation error, got %v", err)
+			}
+			var errs v.ValidationErrors
+			if !errors.As(parseErr.ValidationError, &errs) || len(errs) != 1 || errs[0].Error() != tt.want {
+				t.Fatalf("expected %q, got %v", tt.want, parseErr.ValidationError)
+			}
+		})
+	}
+
+	_, err := newPaymentUnion().Parse(strings.NewReader(`{"type":"crypto"}`))
+	if !errors.Is(err, v.ErrUnknownVariant) {
+		t.Fatalf("expected ErrUnknownVariant, got %v", err)
+	}
+}
+
+func TestUnionMalformedJSON(t *testing.T) {
+	_, err := newPaymentUnion().ParseBytes([]byte(`{"type":`))
+	var parseErr *v.ParseError
+	if !errors.As(err, &parseErr) || parseErr.ParseError == nil {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+}
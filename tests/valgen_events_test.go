@@ -0,0 +1,14 @@
+package tests_test
+
+import "time"
+
+//go:generate go run ../cmd/valgen -output valgen_events_valgo_test.go valgen_events_test.go
+
+// genStamp is a named time type; its rules need no time argument, so only the
+// conversion makes the generated file import time.
+type genStamp time.Time
+
+type genEvent struct {
+	Name string   `json:"name" valgo:"required"`
+	At   genStamp `json:"at" valgo:"required,past"`
+}
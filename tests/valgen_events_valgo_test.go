@@ -0,0 +1,20 @@
+// Code generated by valgen. DO NOT EDIT.
+
+package tests_test
+
+import (
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+// Rules returns the validation rules of genEvent generated from its valgo tags.
+func (s *genEvent) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("name").StringPipe(s.Name, v.NotEmpty()),
+		v.Entry("at").TimePipe(time.Time(s.At), v.NotEmptyDate(), v.BeforeNow()),
+	), nil
+}
+
+// ValgoGenerated tells package v that the valgo tags of genEvent are compiled into Rules.
+func (*genEvent) ValgoGenerated() {}
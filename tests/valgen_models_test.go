@@ -0,0 +1,21 @@
+package tests_test
+
+import "time"
+
+//go:generate go run ../cmd/valgen -output valgen_models_valgo_test.go valgen_models_test.go
+
+type genLevel uint8
+
+type genAccount struct {
+	Name     string    `json:"name" valgo:"required,trim,max=20"`
+	Email    string    `json:"email" valgo:"required,email,max=40"`
+	Age      int       `json:"age"` // valgo: min=18
+	Score    float32   `json:"score" valgo:"gte=0,lte=1"`
+	Level    genLevel  `json:"level" valgo:"max=5"`
+	Code     string    `json:"code" valgo:"pattern=^[A-Z]{2,3}$"`
+	Role     string    `json:"role" valgo:"oneof=admin viewer"`
+	Nickname *string   `json:"nickname" valgo:"min=3"`
+	Referrer *string   `json:"referrer" valgo:"required"`
+	Joined   time.Time `json:"joined" valgo:"after=2020-01-01T00:00:00Z"`
+	Note     string    `json:"note"`
+}
@@ -0,0 +1,28 @@
+// Code generated by valgen. DO NOT EDIT.
+
+package tests_test
+
+import (
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+)
+
+// Rules returns the validation rules of genAccount generated from its valgo tags.
+func (s *genAccount) Rules() (v.PipeSet, error) {
+	return v.NewPipesBuilder(
+		v.Entry("name").Pipe(v.StringPipe(s.Name, v.NotEmpty(), v.Trim(), v.MaxLength(20)).Bind(&s.Name)),
+		v.Entry("email").StringPipe(s.Email, v.NotEmpty(), v.IsEmail(), v.MaxLength(40)),
+		v.Entry("age").IntPipe(s.Age, v.Min(18)),
+		v.Entry("score").FloatPipe(float64(s.Score), v.GteFloat(0), v.LteFloat(1)),
+		v.Entry("level").IntPipe(int(s.Level), v.Max(5)),
+		v.Entry("code").StringPipe(s.Code, v.Pattern(`^[A-Z]{2,3}$`)),
+		v.Entry("role").StringPipe(s.Role, v.Enum([]string{"admin", "viewer"})),
+		v.Entry("nickname").Pipe(v.Optional(s.Nickname, v.MinLength(3))),
+		v.Entry("referrer").Pipe(v.Required(s.Referrer)),
+		v.Entry("joined").TimePipe(s.Joined, v.After(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))),
+	), nil
+}
+
+// ValgoGenerated tells package v that the valgo tags of genAccount are compiled into Rules.
+func (*genAccount) ValgoGenerated() {}
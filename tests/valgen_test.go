@@ -0,0 +1,131 @@
+package tests_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrbns/valgo/lib/v"
+	"github.com/mrbns/valgo/lib/valgen"
+)
+
+func TestValgenOutputIsUpToDate(t *testing.T) {
+	for _, name := range []string{"valgen_models", "valgen_events"} {
+		src, err := os.ReadFile(name + "_test.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := os.ReadFile(name + "_valgo_test.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := valgen.Generate(name+"_test.go", src)
+		if err != nil {
+			t.Fatalf("%s: expected nil, got %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s: generated code is stale, run go generate ./tests\n%s", name, got)
+		}
+	}
+}
+
+func TestValgenGeneratedRules(t *testing.T) {
+	var account genAccount
+	data := `{"name":"  Jane ","email":"jane@example.com","age":30,"code":"BD","role":"admin","referrer":"x","joined":"2024-05-01T00:00:00Z"}`
+	if err := v.ParseBytesFull([]byte(data), &account); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if account.Name != "Jane" {
+		t.Fatalf("expected the trimmed name, got %q", account.Name)
+	}
+
+	data = `{"email":"nope","age":12,"score":2,"level":9,"code":"x","role":"root","nickname":"ab","joined":"2019-01-01T00:00:00Z"}`
+	err := v.ParseBytesFull([]byte(data), &genAccount{})
+	var parseErr *v.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	// tags are not compiled again on top of the generated rules
+	want := "name,email,age,score,level,code,role,nickname,referrer,joined"
+	if got := partialErrorKeys(parseErr.ValidationError); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestValgenRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"unknown rule", "Name string `valgo:\"required,shiny\"`", `User.Name: unknown rule "shiny"`},
+		{"type mismatch", "Age int `valgo:\"email\"`", `User.Age: rule "email" does not apply to int fields`},
+		{"bad argument", "Name string `valgo:\"max=ten\"`", `User.Name: rule "max": "ten" is not an integer`},
+		{"bad pattern", "Name string `valgo:\"pattern=[\"`", `User.Name: rule "pattern"`},
+		{"unsupported type", "Tags []string `valgo:\"required\"`", "User.Tags: unsupported type []string"},
+		{"transform on named type", "Kind kind `valgo:\"trim\"`", "transform rules need a field of type string, got kind"},
+		{"transform on pointer", "Name *string `valgo:\"trim\"`", "transform rules don't apply to pointer fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package models\n\ntype kind string\n\ntype User struct {\n\t" + tt.src + "\n}\n"
+			_, err := valgen.Generate("models.go", []byte(src))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q, got %v", tt.want, err)
+			}
+			if !strings.HasPrefix(err.Error(), "models.go:6:") {
+				t.Fatalf("expected the error position, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValgenSelectsTypes(t *testing.T) {
+	src := []byte("package models\n\ntype A struct {\n\tX string `valgo:\"required\"`\n}\n\ntype B struct {\n\tY int\n}\n")
+
+	out, err := valgen.Generate("models.go", src, "B")
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if strings.Contains(string(out), "func (s *A)") || !strings.Contains(string(out), "func (s *B) Rules()") {
+		t.Fatalf("expected only B, got\n%s", out)
+	}
+
+	if _, err := valgen.Generate("models.go", src, "C"); err == nil {
+		t.Fatal("expected an error for a missing type")
+	}
+}
+
+func TestValgenAcceptsEveryTagRule(t *testing.T) {
+	// args holds a valid argument for the rules that take one.
+	args := map[string]string{
+		"min": "1", "max": "1", "gt": "1", "gte": "1", "lt": "1", "lte": "1",
+		"prefix": "a", "suffix": "a", "contains": "a", "oneof": "a b", "pattern": "^a$",
+		"before": "2024-01-01T00:00:00Z", "after": "2024-01-01T00:00:00Z",
+	}
+	fields := map[string]reflect.Type{
+		"string":    reflect.TypeFor[string](),
+		"int":       reflect.TypeFor[int](),
+		"float64":   reflect.TypeFor[float64](),
+		"time.Time": reflect.TypeFor[time.Time](),
+	}
+
+	for goType, rt := range fields {
+		for _, name := range v.TagRuleNames(rt) {
+			rule := name
+			if arg, ok := args[name]; ok {
+				rule += "=" + arg
+			}
+			src := "package models\n\ntype User struct {\n\tF " + goType + " `valgo:\"" + rule + "\"`\n}\n"
+			if _, err := valgen.Generate("models.go", []byte(src)); err != nil {
+				t.Errorf("%s rule %q: %v", goType, name, err)
+			}
+		}
+	}
+}
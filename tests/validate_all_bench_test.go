@@ -1,6 +1,8 @@
 package tests_test
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"testing"
 
 	"github.com/mrbns/valgo/lib/v"
@@ -45,6 +47,52 @@ func buildBenchStringPipeSet(fieldCount int) v.PipeSet {
 	return v.NewPipesBuilder(pipes...)
 }
 
+// buildBenchExpensivePipeSet simulates custom actions that do real work
+// (hashing here), which is where parallel validation pays off.
+func buildBenchExpensivePipeSet(fieldCount int) v.PipeSet {
+	pipes := make([]v.PipeFace, 0, fieldCount)
+	payload := []byte("bench.user+valgo@example.com")
+
+	expensive := v.CustomAction(func(value []byte) bool {
+		sum := sha256.Sum256(value)
+		for i := 0; i < 2000; i++ {
+			sum = sha256.Sum256(sum[:])
+		}
+		benchSink = sum
+		return true
+	})
+
+	for i := 0; i < fieldCount; i++ {
+		pipes = append(pipes, v.NewPipe(payload, expensive))
+	}
+
+	return v.NewPipesBuilder(pipes...)
+}
+
+var benchSink [sha256.Size]byte
+
+type parallelPipeSet interface {
+	ValidateAllParallel(options ...v.ParallelOption) error
+}
+
+func runValidateAllBench(b *testing.B, pipeSet v.PipeSet, parallel bool) {
+	b.ReportAllocs()
+
+	validate := pipeSet.ValidateAll
+	if parallel {
+		validate = func() error {
+			return pipeSet.(parallelPipeSet).ValidateAllParallel()
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if errs := validate(); errs != nil {
+			b.Fatalf("expected nil errors, got %v", errs)
+		}
+	}
+}
+
 func benchmarkValidateAllIntSerial(b *testing.B, fieldCount int) {
 	b.ReportAllocs()
 	pipeSet := buildBenchIntPipeSet(fieldCount)
@@ -94,3 +142,142 @@ func BenchmarkValidateAllStringSerialSmall(b *testing.B) {
 func BenchmarkValidateAllStringSerialLarge(b *testing.B) {
 	benchmarkValidateAllStringSerial(b, 512)
 }
+
+func BenchmarkValidateAllIntParallelSmall(b *testing.B) {
+	runValidateAllBench(b, buildBenchIntPipeSet(8), true)
+}
+
+func BenchmarkValidateAllIntParallelLarge(b *testing.B) {
+	runValidateAllBench(b, buildBenchIntPipeSet(512), true)
+}
+
+func BenchmarkValidateAllStringParallelSmall(b *testing.B) {
+	runValidateAllBench(b, buildBenchStringPipeSet(8), true)
+}
+
+func BenchmarkValidateAllStringParallelLarge(b *testing.B) {
+	runValidateAllBench(b, buildBenchStringPipeSet(512), true)
+}
+
+func BenchmarkValidateAllExpensiveSerial(b *testing.B) {
+	runValidateAllBench(b, buildBenchExpensivePipeSet(64), false)
+}
+
+func BenchmarkValidateAllExpensiveParallel(b *testing.B) {
+	runValidateAllBench(b, buildBenchExpensivePipeSet(64), true)
+}
+
+// benchPayload holds the values of the compiled schema benchmarks,
+// matching the values of buildBenchIntPipeSet and buildBenchStringPipeSet.
+type benchPayload struct {
+	Ints    []int
+	Strings []string
+}
+
+func newBenchPayload(fieldCount int) *benchPayload {
+	payload := &benchPayload{}
+	for i := 0; i < fieldCount; i++ {
+		payload.Ints = append(payload.Ints, i+100)
+		payload.Strings = append(payload.Strings, "bench.user+valgo@example.com")
+	}
+	return payload
+}
+
+func compileBenchIntSchema(fieldCount int) *v.CompiledSchema[benchPayload] {
+	fields := make([]v.SchemaField[benchPayload], 0, fieldCount)
+	for i := 0; i < fieldCount; i++ {
+		fields = append(fields, v.IntField(fmt.Sprint("int", i), func(p *benchPayload) int { return p.Ints[i] },
+			v.IsPositive(),
+			v.Gt(0),
+			v.Gte(10),
+			v.Min(10),
+			v.Max(1000000),
+			v.Lte(1000000),
+			v.Lt(2000000),
+		))
+	}
+	return v.Compile(fields...)
+}
+
+func compileBenchStringSchema(fieldCount int) *v.CompiledSchema[benchPayload] {
+	fields := make([]v.SchemaField[benchPayload], 0, fieldCount)
+	for i := 0; i < fieldCount; i++ {
+		fields = append(fields, v.StringField(fmt.Sprint("string", i), func(p *benchPayload) string { return p.Strings[i] },
+			v.NotEmpty(),
+			v.MinLength(10),
+			v.MaxLength(64),
+			v.Contains("@"),
+			v.HasSuffix(".com"),
+			v.IsEmail(),
+		))
+	}
+	return v.Compile(fields...)
+}
+
+// runRebuildBench builds the pipe set for every validation, like Schema.Rules does per request.
+func runRebuildBench(b *testing.B, build func(fieldCount int) v.PipeSet, fieldCount int) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if errs := build(fieldCount).ValidateAll(); errs != nil {
+			b.Fatalf("expected nil errors, got %v", errs)
+		}
+	}
+}
+
+func runCompiledBench(b *testing.B, schema *v.CompiledSchema[benchPayload], fieldCount int) {
+	payload := newBenchPayload(fieldCount)
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if errs := schema.ValidateAll(payload); errs != nil {
+			b.Fatalf("expected nil errors, got %v", errs)
+		}
+	}
+}
+
+func BenchmarkValidateAllIntRebuildSmall(b *testing.B) {
+	runRebuildBench(b, buildBenchIntPipeSet, 8)
+}
+
+func BenchmarkValidateAllIntRebuildLarge(b *testing.B) {
+	runRebuildBench(b, buildBenchIntPipeSet, 512)
+}
+
+func BenchmarkValidateAllStringRebuildSmall(b *testing.B) {
+	runRebuildBench(b, buildBenchStringPipeSet, 8)
+}
+
+func BenchmarkValidateAllStringRebuildLarge(b *testing.B) {
+	runRebuildBench(b, buildBenchStringPipeSet, 512)
+}
+
+func BenchmarkValidateAllIntCompiledSmall(b *testing.B) {
+	runCompiledBench(b, compileBenchIntSchema(8), 8)
+}
+
+func BenchmarkValidateAllIntCompiledLarge(b *testing.B) {
+	runCompiledBench(b, compileBenchIntSchema(512), 512)
+}
+
+func BenchmarkValidateAllStringCompiledSmall(b *testing.B) {
+	runCompiledBench(b, compileBenchStringSchema(8), 8)
+}
+
+func BenchmarkValidateAllStringCompiledLarge(b *testing.B) {
+	runCompiledBench(b, compileBenchStringSchema(512), 512)
+}
+
+func BenchmarkValidateAllCompiledParallelCallers(b *testing.B) {
+	schema := compileBenchStringSchema(64)
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		payload := newBenchPayload(64)
+		for pb.Next() {
+			if errs := schema.ValidateAll(payload); errs != nil {
+				b.Fatalf("expected nil errors, got %v", errs)
+			}
+		}
+	})
+}